@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// benchConfig describes one benchmark run
+type benchConfig struct {
+	Mode     string        `json:"mode"`
+	Seeds    int           `json:"seeds"`
+	SeedLen  int           `json:"seed_len"`
+	Workers  int           `json:"workers"`
+	Duration time.Duration `json:"-"`
+}
+
+// benchResult is printed as JSON. Config and environment are included so
+// numbers from different hosts can be compared.
+type benchResult struct {
+	benchConfig
+	Ops        uint64  `json:"ops"`
+	Seconds    float64 `json:"seconds"`
+	OpsPerSec  float64 `json:"ops_per_sec"`
+	GoVersion  string  `json:"go_version"`
+	GOOS       string  `json:"goos"`
+	GOARCH     string  `json:"goarch"`
+	GOMAXPROCS int     `json:"gomaxprocs"`
+}
+
+// benchProgram is the program ID every find/create derivation uses
+var benchProgram = pda.MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+func benchCmd(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	cfg := benchConfig{}
+	fs.StringVar(&cfg.Mode, "mode", "find", "what to measure: find, create or grind")
+	fs.IntVar(&cfg.Seeds, "seeds", 2, "number of seeds per derivation (find, create)")
+	fs.IntVar(&cfg.SeedLen, "seed-len", 32, "length of each seed in bytes (find, create)")
+	fs.IntVar(&cfg.Workers, "workers", 0, "goroutines to run (default GOMAXPROCS)")
+	fs.DurationVar(&cfg.Duration, "duration", 5*time.Second, "how long to run")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	res, err := runBench(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// runBench runs cfg for cfg.Duration (or until ctx is done). Seeds are
+// derived from a per-worker counter, so runs are reproducible.
+func runBench(ctx context.Context, cfg benchConfig) (benchResult, error) {
+	if cfg.Workers < 1 {
+		cfg.Workers = runtime.GOMAXPROCS(0)
+	}
+	if cfg.Seeds < 1 || cfg.Seeds >= pda.MaxSeeds {
+		return benchResult{}, fmt.Errorf("seeds must be between 1 and %d", pda.MaxSeeds-1)
+	}
+	// The first seed holds the worker index, then a u64 counter
+	if cfg.SeedLen < 9 || cfg.SeedLen > pda.MaxSeedLength {
+		return benchResult{}, fmt.Errorf("seed-len must be between 9 and %d", pda.MaxSeedLength)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	var ops uint64
+	start := time.Now()
+
+	switch cfg.Mode {
+	case "find", "create":
+		ops = benchDerive(ctx, cfg)
+	case "grind":
+		// An unreachable 10-character prefix keeps every worker busy until
+		// the deadline; the final progress report carries the attempt count
+		_, err := pda.GrindKeypair(ctx, "1111111111", "", cfg.Workers, pda.WithProgress(func(done uint64, _ float64) {
+			ops = done
+		}, 0))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return benchResult{}, fmt.Errorf("grind: %v", err)
+		}
+	default:
+		return benchResult{}, fmt.Errorf("unknown mode %q", cfg.Mode)
+	}
+
+	elapsed := time.Since(start).Seconds()
+	return benchResult{
+		benchConfig: cfg,
+		Ops:         ops,
+		Seconds:     elapsed,
+		OpsPerSec:   float64(ops) / elapsed,
+		GoVersion:   runtime.Version(),
+		GOOS:        runtime.GOOS,
+		GOARCH:      runtime.GOARCH,
+		GOMAXPROCS:  runtime.GOMAXPROCS(0),
+	}, nil
+}
+
+// benchDerive runs find or create derivations on cfg.Workers goroutines
+// until ctx is done and returns how many completed. On-curve create
+// results count as completed derivations.
+func benchDerive(ctx context.Context, cfg benchConfig) uint64 {
+	var total atomic.Uint64
+	var wg sync.WaitGroup
+
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			seeds := make([][]byte, cfg.Seeds)
+			for i := range seeds {
+				seeds[i] = make([]byte, cfg.SeedLen)
+				seeds[i][0] = byte(w)
+			}
+			if cfg.Mode == "create" {
+				seeds = append(seeds, []byte{255})
+			}
+			input := pda.ProgramDerivedAddressInput{ProgramAddress: benchProgram, Seeds: seeds}
+
+			var n uint64
+			for ctx.Err() == nil {
+				binary.LittleEndian.PutUint64(seeds[0][1:], n)
+				if cfg.Mode == "create" {
+					pda.CreateProgramDerivedAddress(input)
+				} else {
+					pda.GetProgramDerivedAddress(input)
+				}
+				n++
+			}
+			total.Add(n)
+		}()
+	}
+
+	wg.Wait()
+	return total.Load()
+}
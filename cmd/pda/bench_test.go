@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunBench(t *testing.T) {
+	for _, mode := range []string{"find", "create", "grind"} {
+		res, err := runBench(context.Background(), benchConfig{
+			Mode:     mode,
+			Seeds:    2,
+			SeedLen:  32,
+			Workers:  2,
+			Duration: 50 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("%s: runBench failed: %v", mode, err)
+		}
+		if res.Ops == 0 || res.OpsPerSec <= 0 {
+			t.Errorf("%s: expected some operations, got %+v", mode, res)
+		}
+		if res.Workers != 2 || res.Mode != mode {
+			t.Errorf("%s: config not echoed in result: %+v", mode, res)
+		}
+	}
+}
+
+// The shortest seed still fits the worker index and the u64 counter
+func TestRunBench_MinSeedLen(t *testing.T) {
+	res, err := runBench(context.Background(), benchConfig{
+		Mode:     "find",
+		Seeds:    1,
+		SeedLen:  9,
+		Workers:  1,
+		Duration: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("runBench failed: %v", err)
+	}
+	if res.Ops == 0 {
+		t.Errorf("expected some operations, got %+v", res)
+	}
+}
+
+func TestRunBench_InvalidConfig(t *testing.T) {
+	for _, cfg := range []benchConfig{
+		{Mode: "find", Seeds: 16, SeedLen: 32},
+		{Mode: "find", Seeds: 2, SeedLen: 33},
+		{Mode: "find", Seeds: 2, SeedLen: 8},
+		{Mode: "verify", Seeds: 2, SeedLen: 32},
+	} {
+		cfg.Duration = time.Millisecond
+		if _, err := runBench(context.Background(), cfg); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+}
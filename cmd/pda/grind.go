@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+func grindCmd(args []string) error {
+	fs := flag.NewFlagSet("grind", flag.ContinueOnError)
+	prefix := fs.String("prefix", "", "required address prefix")
+	suffix := fs.String("suffix", "", "required address suffix")
+	workers := fs.Int("workers", 0, "goroutines to run (default GOMAXPROCS)")
+	out := fs.String("out", "", "keypair file to write (default <ADDRESS>.json)")
+	checkpoint := fs.String("checkpoint", "", "save resumable search state to this file")
+	interval := fs.Duration("checkpoint-interval", 30*time.Second, "how often to save the checkpoint")
+	resume := fs.Bool("resume", false, "continue the search saved in -checkpoint")
+	force := fs.Bool("force", false, "overwrite an existing keypair file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *prefix == "" && *suffix == "" {
+		return errors.New("-prefix or -suffix is required")
+	}
+	if *resume && *checkpoint == "" {
+		return errors.New("-resume requires -checkpoint")
+	}
+	// Refuse before searching rather than after
+	if *out != "" && !*force {
+		if _, err := os.Stat(*out); err == nil {
+			return fmt.Errorf("%s exists; pass -force to overwrite it", *out)
+		}
+	}
+
+	var opts []pda.GrindOption
+	if *checkpoint != "" {
+		if _, err := os.Stat(*checkpoint); err == nil && !*resume {
+			return fmt.Errorf("checkpoint %s exists; pass -resume to continue it or remove it", *checkpoint)
+		}
+		opts = append(opts, pda.WithCheckpoint(*checkpoint, *interval))
+		if *resume {
+			opts = append(opts, pda.WithResume(*checkpoint))
+		}
+	}
+
+	// Ctrl-C stops the search; the checkpoint is saved on the way out
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	kp, err := pda.GrindKeypair(ctx, *prefix, *suffix, *workers, opts...)
+	if err != nil {
+		if *checkpoint != "" && errors.Is(err, context.Canceled) {
+			return fmt.Errorf("interrupted; resume with -checkpoint %s -resume", *checkpoint)
+		}
+		return err
+	}
+
+	path := *out
+	if path == "" {
+		path = kp.Address().String() + ".json"
+	}
+	if *force {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := pda.SaveKeypairFile(path, kp); err != nil {
+		return err
+	}
+	fmt.Printf("%s\nwrote %s\n", kp.Address(), path)
+	return nil
+}
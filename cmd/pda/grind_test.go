@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+func TestGrindCmd(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "id.json")
+	checkpoint := filepath.Join(dir, "grind.json")
+
+	if err := grindCmd([]string{"-prefix", "A", "-out", out, "-checkpoint", checkpoint}); err != nil {
+		t.Fatalf("grind failed: %v", err)
+	}
+
+	kp, err := pda.LoadKeypairFile(out)
+	if err != nil {
+		t.Fatalf("LoadKeypairFile failed: %v", err)
+	}
+	if !strings.HasPrefix(kp.Address().String(), "A") {
+		t.Errorf("address %s does not start with A", kp.Address())
+	}
+	if _, err := os.Stat(checkpoint); err != nil {
+		t.Errorf("expected a checkpoint file: %v", err)
+	}
+
+	// An existing checkpoint is never silently overwritten
+	if err := grindCmd([]string{"-prefix", "A", "-out", out, "-checkpoint", checkpoint}); err == nil {
+		t.Error("expected an error without -resume")
+	}
+	if err := grindCmd([]string{"-prefix", "A", "-out", out, "-checkpoint", checkpoint, "-resume", "-force"}); err != nil {
+		t.Errorf("resume failed: %v", err)
+	}
+}
+
+func TestGrindCmd_ExistingKeypair(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "id.json")
+	if err := os.WriteFile(out, []byte("keep me"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := grindCmd([]string{"-prefix", "A", "-out", out}); err == nil {
+		t.Fatal("expected an error for an existing -out file")
+	}
+	if data, _ := os.ReadFile(out); string(data) != "keep me" {
+		t.Fatalf("existing keypair file was overwritten: %s", data)
+	}
+
+	if err := grindCmd([]string{"-prefix", "A", "-out", out, "-force"}); err != nil {
+		t.Fatalf("grind -force failed: %v", err)
+	}
+	if _, err := pda.LoadKeypairFile(out); err != nil {
+		t.Errorf("LoadKeypairFile failed after -force: %v", err)
+	}
+}
+
+func TestGrindCmd_Flags(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"-prefix", "A", "-resume"},
+		{"-prefix", "0"},
+	} {
+		if err := grindCmd(args); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Command pda is the command-line front end to the pda package.
+//
+// Usage:
+//
+//	pda <command> [flags]
+//
+// Commands:
+//
+//	bench   measure derivation throughput and print the result as JSON
+//	grind   search for a vanity keypair, with checkpoint and resume
+//	serve   serve derivations over HTTP, optionally with pprof and expvar
+//
+// Run "pda <command> -h" for the flags of each command.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// commands maps each subcommand to its entry point, which receives the
+// arguments after the command name
+var commands = map[string]func(args []string) error{
+	"bench": benchCmd,
+	"grind": grindCmd,
+	"serve": serveCmd,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "pda: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "pda %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: pda <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands: bench, grind, serve")
+}
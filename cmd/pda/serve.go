@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/pprof"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// serveRequest matches the body the Cloudflare worker accepts: seeds are
+// strings (UTF-8) or arrays of byte values
+type serveRequest struct {
+	ProgramID string            `json:"programId"`
+	Seeds     []json.RawMessage `json:"seeds"`
+	Normalize string            `json:"normalize"`
+}
+
+// maxBodyBytes caps a derive request body. The largest valid request, 16
+// seeds of 32 byte values written as JSON arrays, is about 2.5KB.
+const maxBodyBytes = 8 << 10
+
+// serveMetrics are published under "pda" on /debug/vars
+type serveMetrics struct {
+	derivations       *expvar.Int
+	errors            *expvar.Int
+	cacheHits         *expvar.Int
+	cacheMisses       *expvar.Int
+	onCurveRejections *expvar.Int
+}
+
+// metrics is package-level because expvar names can only be published once
+// per process
+var metrics = func() serveMetrics {
+	m := expvar.NewMap("pda")
+	newInt := func(name string) *expvar.Int {
+		v := new(expvar.Int)
+		m.Set(name, v)
+		return v
+	}
+	return serveMetrics{
+		derivations:       newInt("derivations"),
+		errors:            newInt("errors"),
+		cacheHits:         newInt("cache_hits"),
+		cacheMisses:       newInt("cache_misses"),
+		onCurveRejections: newInt("on_curve_rejections"),
+	}
+}()
+
+// countingCache wraps a pda.Cache to count hits and misses
+type countingCache struct {
+	pda.Cache
+}
+
+func (c countingCache) Get(key string) (pda.ProgramDerivedAddressOutput, bool) {
+	out, ok := c.Cache.Get(key)
+	if ok {
+		metrics.cacheHits.Add(1)
+	} else {
+		metrics.cacheMisses.Add(1)
+	}
+	return out, ok
+}
+
+func serveCmd(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	cacheSize := fs.Int("cache", 100000, "LRU cache size in results (0 disables caching)")
+	withPprof := fs.Bool("pprof", false, "serve net/http/pprof handlers under /debug/pprof/")
+	withExpvar := fs.Bool("expvar", false, "serve expvar counters on /debug/vars")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var cache pda.Cache
+	if *cacheSize > 0 {
+		cache = pda.NewLRUCache(*cacheSize)
+	}
+
+	log.Printf("pda: listening on %s", *addr)
+	return http.ListenAndServe(*addr, newServeMux(cache, *withPprof, *withExpvar))
+}
+
+// newServeMux builds the server's routes. The debug handlers are mounted
+// on this mux only, never on http.DefaultServeMux.
+func newServeMux(cache pda.Cache, withPprof, withExpvar bool) *http.ServeMux {
+	opts := []pda.Option{
+		pda.WithTraceHook(func(_ uint8, _ [32]byte, onCurve bool) {
+			if onCurve {
+				metrics.onCurveRejections.Add(1)
+			}
+		}),
+	}
+	if cache != nil {
+		opts = append(opts, pda.WithCache(countingCache{cache}))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /{$}", func(w http.ResponseWriter, r *http.Request) {
+		handleDerive(w, r, opts)
+	})
+
+	if withPprof {
+		mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+		mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+	}
+	if withExpvar {
+		mux.Handle("GET /debug/vars", expvar.Handler())
+	}
+	return mux
+}
+
+func handleDerive(w http.ResponseWriter, r *http.Request, opts []pda.Option) {
+	var req serveRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		if tooLarge := (*http.MaxBytesError)(nil); errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", tooLarge.Limit), pda.CodeInvalidArgument)
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON body: %w", err), pda.CodeInvalidArgument)
+		return
+	}
+
+	form, err := pda.ParseNormalizationForm(req.Normalize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, pda.CodeInvalidArgument)
+		return
+	}
+
+	program, err := pda.NewAddress(req.ProgramID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, pda.ErrorCodeOf(err))
+		return
+	}
+
+	seeds := make([][]byte, len(req.Seeds))
+	for i, raw := range req.Seeds {
+		if seeds[i], err = decodeJSONSeed(raw, form); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("seed %d: %w", i, err), pda.CodeInvalidArgument)
+			return
+		}
+	}
+
+	out, err := pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds}, opts...)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, pda.ErrorCodeOf(err))
+		return
+	}
+
+	metrics.derivations.Add(1)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// decodeJSONSeed accepts a JSON string or an array of byte values
+func decodeJSONSeed(raw json.RawMessage, form pda.NormalizationForm) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		b, _ := pda.NormalizeSeed(s, form)
+		return b, nil
+	}
+
+	var values []uint8
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, errors.New("seed must be a string or an array of bytes")
+	}
+	return values, nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error, code pda.ErrorCode) {
+	metrics.errors.Add(1)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error(), "code": string(code)})
+}
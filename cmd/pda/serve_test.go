@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+func post(t *testing.T, h http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return rec
+}
+
+func TestServe_Derive(t *testing.T) {
+	mux := newServeMux(pda.NewLRUCache(8), false, false)
+
+	rec := post(t, mux, `{"programId":"BPFLoaderUpgradeab1e11111111111111111111111","seeds":["Talking",[83,113,117,105,114,114,101,108,115]]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var out pda.ProgramDerivedAddressOutput
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	want, _ := pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: pda.MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111"),
+		Seeds:          [][]byte{[]byte("Talking"), []byte("Squirrels")},
+	})
+	if out != want {
+		t.Errorf("got %+v, want %+v", out, want)
+	}
+}
+
+func TestServe_Errors(t *testing.T) {
+	mux := newServeMux(nil, false, false)
+
+	tests := []struct {
+		body string
+		code pda.ErrorCode
+	}{
+		{`not json`, pda.CodeInvalidArgument},
+		{`{"programId":"bad!","seeds":[]}`, pda.CodeBadBase58},
+		{`{"programId":"11111111111111111111111111111111","seeds":[true]}`, pda.CodeInvalidArgument},
+		{`{"programId":"11111111111111111111111111111111","seeds":["` + strings.Repeat("a", 33) + `"]}`, pda.CodeSeedTooLong},
+	}
+	for _, tt := range tests {
+		rec := post(t, mux, tt.body)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", tt.body, rec.Code)
+		}
+		var body map[string]string
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		if body["code"] != string(tt.code) {
+			t.Errorf("%s: expected code %s, got %v", tt.body, tt.code, body)
+		}
+	}
+}
+
+func TestServe_BodyTooLarge(t *testing.T) {
+	mux := newServeMux(nil, false, false)
+
+	seed := `"` + strings.Repeat("a", maxBodyBytes) + `"`
+	rec := post(t, mux, `{"programId":"11111111111111111111111111111111","seeds":[`+seed+`]}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d: %s", rec.Code, rec.Body)
+	}
+
+	// The largest valid request fits
+	seeds := make([]string, pda.MaxSeeds-1)
+	for i := range seeds {
+		seeds[i] = "[" + strings.TrimSuffix(strings.Repeat("255,", pda.MaxSeedLength), ",") + "]"
+	}
+	rec = post(t, mux, `{"programId":"11111111111111111111111111111111","seeds":[`+strings.Join(seeds, ",")+`],"normalize":"none"}`)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestServe_DebugEndpoints(t *testing.T) {
+	get := func(mux http.Handler, path string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	off := newServeMux(nil, false, false)
+	for _, path := range []string{"/debug/pprof/", "/debug/vars"} {
+		if code := get(off, path); code == http.StatusOK {
+			t.Errorf("%s should not be served unless enabled", path)
+		}
+	}
+
+	on := newServeMux(pda.NewLRUCache(8), true, true)
+	for _, path := range []string{"/debug/pprof/", "/debug/vars"} {
+		if code := get(on, path); code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, code)
+		}
+	}
+
+	body := `{"programId":"11111111111111111111111111111111","seeds":["metrics"]}`
+	post(t, on, body)
+	post(t, on, body)
+	if metrics.cacheHits.Value() < 1 || metrics.derivations.Value() < 2 {
+		t.Errorf("expected counters to move, got hits=%d derivations=%d", metrics.cacheHits.Value(), metrics.derivations.Value())
+	}
+
+	rec := httptest.NewRecorder()
+	on.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	if !strings.Contains(rec.Body.String(), `"on_curve_rejections"`) {
+		t.Errorf("expected pda counters in /debug/vars, got: %s", rec.Body)
+	}
+}
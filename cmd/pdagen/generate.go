@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"text/template"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+const defaultPDAImport = "raccoon-wasm/pkg/pda"
+
+// Schema is the JSON input of pdagen:
+//
+//	{
+//	  "package": "accounts",
+//	  "accounts": [
+//	    {
+//	      "name": "Vault",
+//	      "program": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+//	      "seeds": [
+//	        {"type": "str", "value": "vault"},
+//	        {"name": "owner", "type": "pubkey"},
+//	        {"name": "index", "type": "u64"}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// Seeds with a value are constants; seeds with a name become parameters.
+type Schema struct {
+	Package  string    `json:"package"`
+	Accounts []Account `json:"accounts"`
+}
+
+// Account describes one PDA: its program and ordered seeds
+type Account struct {
+	Name    string `json:"name"`
+	Program string `json:"program"`
+	Seeds   []Seed `json:"seeds"`
+}
+
+// Seed is either a constant (Value set) or a typed parameter (Name set).
+// Types follow pda.ParseSeedSpec.
+type Seed struct {
+	Name  string  `json:"name,omitempty"`
+	Type  string  `json:"type"`
+	Value *string `json:"value,omitempty"`
+}
+
+// paramTypes maps seed types to the Go parameter type and the builder call
+// that encodes it (%s is the parameter name)
+var paramTypes = map[string]struct{ goType, call string }{
+	"str":    {"string", ".String(%s)"},
+	"string": {"string", ".String(%s)"},
+	"bytes":  {"[]byte", ".Bytes(%s)"},
+	"u8":     {"uint8", ".U8(%s)"},
+	"u16":    {"uint16", ".U16LE(%s)"},
+	"u16be":  {"uint16", ".Bytes(pda.SeedU16BE(%s))"},
+	"u32":    {"uint32", ".U32LE(%s)"},
+	"u32be":  {"uint32", ".Bytes(pda.SeedU32BE(%s))"},
+	"u64":    {"uint64", ".U64LE(%s)"},
+	"u64be":  {"uint64", ".Bytes(pda.SeedU64BE(%s))"},
+	"pubkey": {"pda.Address", ".Address(%s)"},
+}
+
+type accountData struct {
+	Name    string
+	Program string
+	Params  string
+	Calls   string
+}
+
+var fileTemplate = template.Must(template.New("pdagen").Parse(`// Code generated by pdagen. DO NOT EDIT.
+
+package {{.Package}}
+
+import pda "{{.Import}}"
+{{range .Accounts}}
+// {{.Name}}Program is the program that owns {{.Name}} PDAs
+var {{.Name}}Program = pda.MustNewAddress("{{.Program}}")
+
+// Find{{.Name}}PDA derives the {{.Name}} PDA and its canonical bump
+func Find{{.Name}}PDA({{.Params}}) (pda.ProgramDerivedAddressOutput, error) {
+	return pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: {{.Name}}Program,
+		Seeds:          pda.NewSeeds(){{.Calls}}.Build(),
+	})
+}
+{{end}}`))
+
+// Generate renders the Go source for schema
+func Generate(schema Schema, pdaImport string) ([]byte, error) {
+	if !token.IsIdentifier(schema.Package) {
+		return nil, fmt.Errorf("invalid package name %q", schema.Package)
+	}
+	if pdaImport == "" {
+		pdaImport = defaultPDAImport
+	}
+
+	accounts := make([]accountData, 0, len(schema.Accounts))
+	for _, acct := range schema.Accounts {
+		data, err := accountFor(acct)
+		if err != nil {
+			return nil, fmt.Errorf("account %q: %w", acct.Name, err)
+		}
+		accounts = append(accounts, data)
+	}
+
+	var buf bytes.Buffer
+	err := fileTemplate.Execute(&buf, map[string]any{
+		"Package":  schema.Package,
+		"Import":   pdaImport,
+		"Accounts": accounts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func accountFor(acct Account) (accountData, error) {
+	if !token.IsIdentifier(acct.Name) || !token.IsExported(acct.Name) {
+		return accountData{}, fmt.Errorf("name must be an exported Go identifier")
+	}
+	if _, err := pda.NewAddress(acct.Program); err != nil {
+		return accountData{}, fmt.Errorf("program: %w", err)
+	}
+
+	var params []string
+	var calls strings.Builder
+	for i, seed := range acct.Seeds {
+		if seed.Value != nil {
+			call, err := constantCall(seed)
+			if err != nil {
+				return accountData{}, fmt.Errorf("seed %d: %w", i, err)
+			}
+			calls.WriteString(call)
+			continue
+		}
+
+		pt, ok := paramTypes[seed.Type]
+		if !ok {
+			return accountData{}, fmt.Errorf("seed %d: unsupported parameter type %q", i, seed.Type)
+		}
+		if !token.IsIdentifier(seed.Name) || token.IsKeyword(seed.Name) {
+			return accountData{}, fmt.Errorf("seed %d: invalid parameter name %q", i, seed.Name)
+		}
+		params = append(params, seed.Name+" "+pt.goType)
+		fmt.Fprintf(&calls, pt.call, seed.Name)
+	}
+
+	return accountData{
+		Name:    acct.Name,
+		Program: acct.Program,
+		Params:  strings.Join(params, ", "),
+		Calls:   calls.String(),
+	}, nil
+}
+
+// constantCall encodes a constant seed at generation time
+func constantCall(seed Seed) (string, error) {
+	if seed.Type == "str" || seed.Type == "string" {
+		return fmt.Sprintf(".String(%q)", *seed.Value), nil
+	}
+
+	b, err := pda.ParseSeedSpec(seed.Type + ":" + *seed.Value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(".Bytes(%#v)", b[0]), nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+  "package": "accounts",
+  "accounts": [
+    {
+      "name": "Vault",
+      "program": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+      "seeds": [
+        {"type": "str", "value": "vault"},
+        {"name": "owner", "type": "pubkey"},
+        {"name": "index", "type": "u64"},
+        {"type": "u16", "value": "7"}
+      ]
+    }
+  ]
+}`
+
+func TestGenerate(t *testing.T) {
+	var schema Schema
+	if err := json.Unmarshal([]byte(testSchema), &schema); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	src, err := Generate(schema, "")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	out := string(src)
+	for _, want := range []string{
+		"// Code generated by pdagen. DO NOT EDIT.",
+		"package accounts",
+		"func FindVaultPDA(owner pda.Address, index uint64) (pda.ProgramDerivedAddressOutput, error)",
+		`pda.NewSeeds().String("vault").Address(owner).U64LE(index).Bytes([]byte{0x7, 0x0}).Build()`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []Schema{
+		{Package: "accounts", Accounts: []Account{{Name: "vault", Program: "11111111111111111111111111111111"}}},
+		{Package: "accounts", Accounts: []Account{{Name: "Vault", Program: "not-base58!"}}},
+		{Package: "accounts", Accounts: []Account{{Name: "Vault", Program: "11111111111111111111111111111111", Seeds: []Seed{{Name: "x", Type: "f64"}}}}},
+		{Package: "accounts", Accounts: []Account{{Name: "Vault", Program: "11111111111111111111111111111111", Seeds: []Seed{{Name: "type", Type: "u8"}}}}},
+		{Package: "bad-name"},
+	}
+
+	for i, schema := range tests {
+		if _, err := Generate(schema, ""); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Command pdagen generates strongly typed PDA helper functions from a JSON
+// seed schema. It is meant to be driven by go:generate:
+//
+//	//go:generate go run raccoon-wasm/cmd/pdagen -in accounts.json -out accounts_pda.go
+//
+// See Schema for the input format.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	in := flag.String("in", "", "path to the JSON seed schema")
+	out := flag.String("out", "", "path of the generated Go file (default stdout)")
+	pdaImport := flag.String("pda-import", defaultPDAImport, "import path of the pda package")
+	flag.Parse()
+
+	if *in == "" {
+		fmt.Fprintln(os.Stderr, "pdagen: -in is required")
+		os.Exit(2)
+	}
+
+	if err := run(*in, *out, *pdaImport); err != nil {
+		fmt.Fprintf(os.Stderr, "pdagen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pdaImport string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return fmt.Errorf("parse %s: %w", in, err)
+	}
+
+	src, err := Generate(schema, pdaImport)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
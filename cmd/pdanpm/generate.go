@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"regexp"
+	"text/template"
+)
+
+const (
+	defaultName    = "go-pda"
+	defaultVersion = "0.0.0"
+
+	// wasmFile is the module's name in the package, next to the loader
+	wasmFile = "main.wasm"
+	// programsFile is the name of the optional protocol helpers module
+	programsFile = "programs.wasm"
+)
+
+// Package describes the generated npm package
+type Package struct {
+	Name    string
+	Version string
+	// Programs includes the protocol helpers module (cmd/wasmprograms),
+	// which the loader fetches the first time one of its functions is
+	// called
+	Programs bool
+}
+
+// Wrapper is one function exported by the loader. It awaits the module
+// and calls Method on the bridge namespace with the same arguments.
+type Wrapper struct {
+	Export  string // name exported to JavaScript
+	Method  string // bridge function it calls
+	Params  string // TypeScript parameter list
+	Returns string // TypeScript type the Promise resolves to
+	Doc     string
+}
+
+// wrappers lists the exported functions, in the order they are emitted
+var wrappers = []Wrapper{
+	{"findPda", "find", "programId: AddressInput, seeds: SeedInput[], options?: PdaOptions", "PdaResult", "Derives the canonical PDA and its bump."},
+	{"findPdas", "getProgramDerivedAddresses", "requests: PdaRequest[], options?: PdaOptions", "(PdaResult | PdaError)[]", "Derives a PDA per request; failed requests yield an Error in their place."},
+	{"createPda", "create", "programId: AddressInput, seeds: SeedInput[], options?: PdaOptions", "{ address: string; addressBytes?: Uint8Array }", "Derives the address for seeds used as-is, bump included."},
+	{"getAllValidBumps", "getAllValidBumps", "programId: AddressInput, seeds: SeedInput[], options?: PdaOptions", "number[]", "Lists every off-curve bump, 255 down to 0; the first is canonical."},
+	{"createAddressWithSeed", "createAddressWithSeed", "base: AddressInput, seed: string, owner: AddressInput, options?: PdaOptions", "{ address: string; addressBytes?: Uint8Array }", "Derives the address of an account created with CreateAccountWithSeed."},
+	{"isOnCurve", "isOnCurve", "address: AddressInput", "boolean", "Reports whether the address is an ed25519 point (a keypair, not a PDA)."},
+	{"deriveRange", "deriveRange", "programId: AddressInput, prefixSeeds: SeedInput[], start: number | bigint | string, end: number | bigint | string, options?: PdaOptions", "(PdaResult & { index: number | bigint | string })[]", "Derives the PDA for prefixSeeds + u64le(index) for every index in [start, end)."},
+	{"grindPda", "grind", "programId: AddressInput, fixedSeeds: SeedInput[], options: GrindOptions", "PdaResult & { seed: Uint8Array }", "Searches for a PDA matching a prefix/suffix by appending a u64 counter seed."},
+	{"encodeBase58", "encodeBase58", "bytes: Uint8Array", "string", "Encodes bytes of any length as base58."},
+	{"decodeBase58", "decodeBase58", "text: string", "Uint8Array", "Decodes base58 text of any length."},
+}
+
+// programWrappers lists the functions of the protocol helpers module,
+// exported when the package includes it
+var programWrappers = []Wrapper{
+	{"getAssociatedTokenAddress", "getAssociatedTokenAddress", "owner: AddressInput, mint: AddressInput, options?: AtaOptions", "PdaResult", "Derives the associated token account of owner for a mint of the Token program, or of options.tokenProgram."},
+	{"getAssociatedTokenAddress2022", "getAssociatedTokenAddress2022", "owner: AddressInput, mint: AddressInput, options?: PdaOptions", "PdaResult", "Derives the associated token account of owner for a mint of the Token-2022 program."},
+	{"findMetadataPda", "findMetadataPda", "mint: AddressInput, options?: PdaOptions", "PdaResult", "Derives the Metaplex metadata account of mint."},
+	{"findMasterEditionPda", "findMasterEditionPda", "mint: AddressInput, options?: PdaOptions", "PdaResult", "Derives the Metaplex master edition account of mint."},
+	{"findEditionMarkerPda", "findEditionMarkerPda", "mint: AddressInput, edition: number | bigint | string, options?: PdaOptions", "PdaResult", "Derives the Metaplex edition marker account recording whether edition of mint was printed."},
+	{"findCandyMachineAuthorityPda", "findCandyMachineAuthorityPda", "candyMachine: AddressInput, options?: PdaOptions", "PdaResult", "Derives the authority PDA of a Candy Machine v3."},
+	{"findCandyGuardPda", "findCandyGuardPda", "base: AddressInput, options?: PdaOptions", "PdaResult", "Derives the candy guard account created from base."},
+}
+
+//go:embed templates
+var templates embed.FS
+
+var tmpl = template.Must(template.New("").ParseFS(templates, "templates/*.tmpl"))
+
+// validName and validVersion match the npm package names and (semver)
+// versions the generator accepts
+var (
+	validName    = regexp.MustCompile(`^(@[a-z0-9][a-z0-9._-]*/)?[a-z0-9][a-z0-9._-]*$`)
+	validVersion = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+)
+
+// Generate renders the package files other than the module itself:
+// index.mjs (the loader, with wasmExec bundled in), index.d.ts and
+// package.json. wasmExec must be the wasm_exec.js of the toolchain that
+// built the module.
+func Generate(pkg Package, wasmExec []byte) (map[string][]byte, error) {
+	if !validName.MatchString(pkg.Name) {
+		return nil, fmt.Errorf("invalid package name %q", pkg.Name)
+	}
+	if !validVersion.MatchString(pkg.Version) {
+		return nil, fmt.Errorf("invalid package version %q", pkg.Version)
+	}
+
+	data := struct {
+		Package
+		WasmFile        string
+		ProgramsFile    string
+		WasmExec        string
+		Wrappers        []Wrapper
+		ProgramWrappers []Wrapper
+	}{pkg, wasmFile, programsFile, string(wasmExec), wrappers, programWrappers}
+
+	files := map[string][]byte{}
+	for file, name := range map[string]string{
+		"index.mjs":    "index.mjs.tmpl",
+		"index.d.ts":   "index.d.ts.tmpl",
+		"package.json": "package.json.tmpl",
+	} {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return nil, fmt.Errorf("render %s: %w", file, err)
+		}
+		files[file] = buf.Bytes()
+	}
+	return files, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	files, err := Generate(Package{Name: "@acme/pda", Version: "1.2.3"}, []byte("// wasm_exec.js glue\n"))
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	loader := string(files["index.mjs"])
+	for _, want := range []string{
+		"// Code generated by pdanpm. DO NOT EDIT.",
+		"// wasm_exec.js glue",
+		"WebAssembly.instantiateStreaming",
+		"Deno.readFile(url)",
+		`(await import("node:crypto")).webcrypto`,
+		`new URL("./main.wasm", import.meta.url)`,
+		"export async function findPda(...args) {\n\treturn (await init()).find(...args);\n}",
+		"export async function grindPda(...args) {\n\treturn (await init()).grind(...args);\n}",
+	} {
+		if !strings.Contains(loader, want) {
+			t.Errorf("index.mjs is missing %q", want)
+		}
+	}
+
+	types := string(files["index.d.ts"])
+	for _, w := range wrappers {
+		if want := "export function " + w.Export + "(" + w.Params + "): Promise<" + w.Returns + ">;"; !strings.Contains(types, want) {
+			t.Errorf("index.d.ts is missing %q", want)
+		}
+	}
+
+	var manifest struct {
+		Name    string
+		Version string
+		Files   []string
+	}
+	if err := json.Unmarshal(files["package.json"], &manifest); err != nil {
+		t.Fatalf("package.json is not valid JSON: %v", err)
+	}
+	if manifest.Name != "@acme/pda" || manifest.Version != "1.2.3" || len(manifest.Files) != 3 {
+		t.Errorf("unexpected package.json %+v", manifest)
+	}
+	if strings.Contains(loader, "initPrograms") {
+		t.Error("index.mjs loads the programs module without Programs set")
+	}
+}
+
+func TestGenerate_Programs(t *testing.T) {
+	files, err := Generate(Package{Name: "go-pda", Version: "1.0.0", Programs: true}, nil)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	loader := string(files["index.mjs"])
+	for _, want := range []string{
+		`export function initPrograms(source = new URL("./programs.wasm", import.meta.url)) {`,
+		"export async function getAssociatedTokenAddress(...args) {\n\treturn (await initPrograms()).getAssociatedTokenAddress(...args);\n}",
+	} {
+		if !strings.Contains(loader, want) {
+			t.Errorf("index.mjs is missing %q", want)
+		}
+	}
+
+	types := string(files["index.d.ts"])
+	for _, w := range programWrappers {
+		if want := "export function " + w.Export + "(" + w.Params + "): Promise<" + w.Returns + ">;"; !strings.Contains(types, want) {
+			t.Errorf("index.d.ts is missing %q", want)
+		}
+	}
+
+	var manifest struct{ Files []string }
+	if err := json.Unmarshal(files["package.json"], &manifest); err != nil {
+		t.Fatalf("package.json is not valid JSON: %v", err)
+	}
+	if len(manifest.Files) != 4 || manifest.Files[3] != "programs.wasm" {
+		t.Errorf("unexpected package.json files %v", manifest.Files)
+	}
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	for _, name := range []string{"", "Go-PDA", "../pda", `a"b`} {
+		if _, err := Generate(Package{Name: name, Version: "1.0.0"}, nil); err == nil {
+			t.Errorf("expected an error for package name %q", name)
+		}
+	}
+	for _, version := range []string{"", "1.0", `1.0.0"`} {
+		if _, err := Generate(Package{Name: "go-pda", Version: version}, nil); err == nil {
+			t.Errorf("expected an error for version %q", version)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Command pdanpm generates an npm package around the WASM bridge: the
+// module, an ES module loader with wasm_exec.js bundled in, and TypeScript
+// types, so JavaScript consumers can write
+//
+//	import { findPda } from "go-pda";
+//
+// instead of wiring up wasm_exec.js and WebAssembly.instantiate by hand.
+// Build the module first, then run:
+//
+//	GOOS=js GOARCH=wasm go build -o main.wasm ./cmd/wasm
+//	go run ./cmd/pdanpm -wasm main.wasm -out dist/go-pda
+//
+// To include the protocol helpers, build cmd/wasmprograms as well and pass
+// it with -programs-wasm; the loader fetches it only when one of its
+// functions is first called.
+//
+// The templates are embedded in the command; see Generate.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	wasm := flag.String("wasm", "", "path to the module built from ./cmd/wasm")
+	programs := flag.String("programs-wasm", "", "path to the module built from ./cmd/wasmprograms (optional)")
+	wasmExec := flag.String("wasm-exec", "", "path to wasm_exec.js (default: the one of the go command's GOROOT)")
+	out := flag.String("out", "", "directory to write the package to")
+	name := flag.String("name", defaultName, "npm package name")
+	version := flag.String("version", defaultVersion, "npm package version")
+	flag.Parse()
+
+	if *wasm == "" || *out == "" {
+		fmt.Fprintln(os.Stderr, "pdanpm: -wasm and -out are required")
+		os.Exit(2)
+	}
+
+	if err := run(*wasm, *programs, *wasmExec, *out, Package{Name: *name, Version: *version, Programs: *programs != ""}); err != nil {
+		fmt.Fprintf(os.Stderr, "pdanpm: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(wasm, programs, wasmExec, out string, pkg Package) error {
+	if wasmExec == "" {
+		goroot, err := exec.Command("go", "env", "GOROOT").Output()
+		if err != nil {
+			return fmt.Errorf("locate wasm_exec.js: %w", err)
+		}
+		wasmExec = filepath.Join(strings.TrimSpace(string(goroot)), "lib", "wasm", "wasm_exec.js")
+	}
+
+	module, err := os.ReadFile(wasm)
+	if err != nil {
+		return err
+	}
+	glue, err := os.ReadFile(wasmExec)
+	if err != nil {
+		return err
+	}
+
+	files, err := Generate(pkg, glue)
+	if err != nil {
+		return err
+	}
+	files[wasmFile] = module
+	if programs != "" {
+		if files[programsFile], err = os.ReadFile(programs); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(out, name), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
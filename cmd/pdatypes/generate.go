@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Bridge is the JavaScript surface described by the bridge's source
+type Bridge struct {
+	// Decls are the type declarations, in source order
+	Decls []string
+	// Interfaces are the namespace objects, one per Register function
+	Interfaces []Interface
+	// ErrorCodes are the values of the pda.ErrorCode constants
+	ErrorCodes []string
+	// SeedKinds are the seed types pda.ParseTypedSeed accepts
+	SeedKinds []string
+}
+
+// Interface is a namespace object: SolanaPda for the functions of
+// Register, SolanaPdaX for those of RegisterX
+type Interface struct {
+	Name string
+	// Methods are the registered functions, in registration order
+	Methods []Method
+}
+
+// Method is one function of a namespace object
+type Method struct {
+	Name       string
+	Doc        string
+	Signatures []string
+}
+
+// tsMarker introduces the TypeScript block of a doc comment
+const tsMarker = "TypeScript:"
+
+// Load reads the bridge's functions from the Go files in bridgeDir and the
+// error codes and typed seed kinds from those in pdaDir.
+//
+// A function's doc comment may end with a "TypeScript:" line followed by a
+// code block. Lines starting with "type" or "interface" begin declarations,
+// which are emitted as they are; any other line is a method signature and
+// may only appear on a function registered by Register (or another
+// Register function, such as RegisterPrograms), whose doc comment's first
+// paragraph becomes the method's documentation. Every
+// registered function needs a signature, and every property a function
+// reads with get(v, "name") must be declared in its block.
+func Load(bridgeDir, pdaDir string) (*Bridge, error) {
+	files, err := parseDir(bridgeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := map[string]*ast.FuncDecl{}
+	var order []*ast.FuncDecl
+	for _, f := range files {
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil {
+				funcs[fn.Name.Name] = fn
+				order = append(order, fn)
+			}
+		}
+	}
+
+	// The functions registered by each Register function, and the names
+	// each Go function is registered under
+	var registers []*ast.FuncDecl
+	regsOf := map[*ast.FuncDecl][]registration{}
+	bridgeFuncs := map[string][]string{}
+	for _, fn := range order {
+		if !strings.HasPrefix(fn.Name.Name, "Register") {
+			continue
+		}
+		regs, err := registered(fn)
+		if err != nil {
+			return nil, err
+		}
+		registers = append(registers, fn)
+		regsOf[fn] = regs
+		for _, r := range regs {
+			bridgeFuncs[r.fn] = append(bridgeFuncs[r.fn], r.name)
+		}
+	}
+	if len(registers) == 0 {
+		return nil, fmt.Errorf("no Register function")
+	}
+
+	b := &Bridge{}
+	signatures := map[string][]string{}
+	for _, fn := range order {
+		lines := tsBlock(fn.Doc)
+		if lines == nil {
+			continue
+		}
+		decls, sigs, err := splitBlock(lines)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.Name.Name, err)
+		}
+		if err := checkKeys(fn, strings.Join(lines, "\n")); err != nil {
+			return nil, err
+		}
+		b.Decls = append(b.Decls, decls...)
+
+		if len(sigs) == 0 {
+			continue
+		}
+		names, ok := bridgeFuncs[fn.Name.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: TypeScript signature outside a bridge function", fn.Name.Name)
+		}
+		for _, sig := range sigs {
+			if !slices.ContainsFunc(names, func(name string) bool { return strings.HasPrefix(sig, name+"(") }) {
+				return nil, fmt.Errorf("%s: signature %q is not for %s", fn.Name.Name, sig, strings.Join(names, " or "))
+			}
+			name, _, _ := strings.Cut(sig, "(")
+			signatures[name] = append(signatures[name], sig)
+		}
+	}
+
+	for _, register := range registers {
+		iface := Interface{Name: "SolanaPda" + strings.TrimPrefix(register.Name.Name, "Register")}
+		for _, r := range regsOf[register] {
+			if len(signatures[r.name]) == 0 {
+				return nil, fmt.Errorf("bridge function %s (%s) has no TypeScript signature", r.name, r.fn)
+			}
+			iface.Methods = append(iface.Methods, Method{
+				Name:       r.name,
+				Doc:        summary(funcs[r.fn]),
+				Signatures: signatures[r.name],
+			})
+		}
+		b.Interfaces = append(b.Interfaces, iface)
+	}
+
+	if b.ErrorCodes, b.SeedKinds, err = loadPDA(pdaDir); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// parseDir parses the non-test Go files of dir, whatever their build
+// constraints, in name order
+func parseDir(dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return files, nil
+}
+
+// registration is one set(name, fn) call in a Register function
+type registration struct {
+	name string // name on the namespace object
+	fn   string // Go function that implements it
+}
+
+// registered lists the functions register adds to its namespace. A
+// function literal is implemented by the Go function of the same name.
+func registered(register *ast.FuncDecl) ([]registration, error) {
+	var regs []registration
+	ast.Inspect(register.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		if id, ok := call.Fun.(*ast.Ident); !ok || id.Name != "set" {
+			return true
+		}
+		name, ok := stringLit(call.Args[0])
+		if !ok {
+			return true
+		}
+		r := registration{name: name, fn: name}
+		if id, ok := call.Args[1].(*ast.Ident); ok {
+			r.fn = id.Name
+		}
+		regs = append(regs, r)
+		return true
+	})
+	if len(regs) == 0 {
+		return nil, fmt.Errorf("%s sets no functions", register.Name.Name)
+	}
+	return regs, nil
+}
+
+// tsBlock returns the lines of the TypeScript block of doc, or nil
+func tsBlock(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+
+	var lines []string
+	in := false
+	for _, c := range doc.List {
+		text := strings.TrimPrefix(c.Text, "//")
+		switch {
+		case !in:
+			in = strings.TrimSpace(text) == tsMarker
+		case strings.HasPrefix(text, "\t"):
+			lines = append(lines, text[1:])
+		case strings.TrimSpace(text) == "":
+		default:
+			return lines
+		}
+	}
+	return lines
+}
+
+// splitBlock splits a TypeScript block into declarations and signatures.
+// Comment lines belong to what follows them; indented lines and lines
+// starting with "}" or "|" continue what precedes them.
+func splitBlock(lines []string) (decls, sigs []string, err error) {
+	var items [][]string
+	var pending []string
+	for _, l := range lines {
+		switch {
+		case l == "":
+		case strings.HasPrefix(l, "//"):
+			pending = append(pending, l)
+		case strings.HasPrefix(l, " ") || strings.HasPrefix(l, "}") || strings.HasPrefix(l, "|"):
+			if len(items) == 0 {
+				return nil, nil, fmt.Errorf("TypeScript block starts with a continuation: %q", l)
+			}
+			items[len(items)-1] = append(items[len(items)-1], l)
+		default:
+			items = append(items, append(pending, l))
+			pending = nil
+		}
+	}
+
+	for _, item := range items {
+		head := item[len(item)-1]
+		for _, l := range item {
+			if !strings.HasPrefix(l, "//") {
+				head = l
+				break
+			}
+		}
+		text := strings.Join(item, "\n")
+		if strings.HasPrefix(head, "type ") || strings.HasPrefix(head, "interface ") {
+			decls = append(decls, text)
+		} else {
+			sigs = append(sigs, text)
+		}
+	}
+	return decls, sigs, nil
+}
+
+// checkKeys reports a property fn reads with get(v, "name") that its
+// TypeScript block does not declare
+func checkKeys(fn *ast.FuncDecl, block string) (err error) {
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 || err != nil {
+			return err == nil
+		}
+		if id, ok := call.Fun.(*ast.Ident); !ok || id.Name != "get" {
+			return true
+		}
+		key, ok := stringLit(call.Args[1])
+		if !ok {
+			return true
+		}
+		if !regexp.MustCompile(`\b` + regexp.QuoteMeta(key) + `\??:`).MatchString(block) {
+			err = fmt.Errorf("%s reads %q, which its TypeScript block does not declare", fn.Name.Name, key)
+		}
+		return true
+	})
+	return err
+}
+
+// summary returns the first paragraph of fn's doc comment as a sentence
+// about the method, without the "args:" line
+func summary(fn *ast.FuncDecl) string {
+	if fn == nil || fn.Doc == nil {
+		return ""
+	}
+	para, _, _ := strings.Cut(fn.Doc.Text(), "\n\n")
+
+	var words []string
+	for _, l := range strings.Split(para, "\n") {
+		if strings.HasPrefix(l, "args:") {
+			continue
+		}
+		words = append(words, strings.Fields(l)...)
+	}
+	if len(words) > 0 && words[0] == fn.Name.Name {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return ""
+	}
+	r, n := utf8.DecodeRuneInString(words[0])
+	words[0] = string(unicode.ToUpper(r)) + words[0][n:]
+	return strings.Join(words, " ")
+}
+
+// loadPDA reads the values of the ErrorCode constants and the seed types
+// of ParseTypedSeed from the pda package's source
+func loadPDA(dir string) (codes, kinds []string, err error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				if id, ok := n.Type.(*ast.Ident); ok && id.Name == "ErrorCode" {
+					for _, v := range n.Values {
+						if s, ok := stringLit(v); ok {
+							codes = append(codes, s)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if n.Name.Name != "ParseTypedSeed" || n.Recv != nil {
+					return false
+				}
+				ast.Inspect(n.Body, func(n ast.Node) bool {
+					if c, ok := n.(*ast.CaseClause); ok {
+						for _, e := range c.List {
+							if s, ok := stringLit(e); ok && !slices.Contains(kinds, s) {
+								kinds = append(kinds, s)
+							}
+						}
+					}
+					return true
+				})
+				return false
+			}
+			return true
+		})
+	}
+
+	if len(codes) == 0 {
+		return nil, nil, fmt.Errorf("no ErrorCode constants in %s", dir)
+	}
+	if len(kinds) == 0 {
+		return nil, nil, fmt.Errorf("no ParseTypedSeed cases in %s", dir)
+	}
+	return codes, kinds, nil
+}
+
+// stringLit returns the value of a string literal expression
+func stringLit(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// Generate renders b as a script (global) declaration file
+func Generate(b *Bridge) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by pdatypes. DO NOT EDIT.\n\n")
+	buf.WriteString("// The functions the Go bridge (pkg/wasmbridge) registers on its namespace\n")
+	buf.WriteString("// object, globalThis.solanaPda by default, and those of the protocol helpers\n")
+	buf.WriteString("// module (cmd/wasmprograms) on globalThis.solanaPdaPrograms. Failures throw\n")
+	buf.WriteString("// a PdaError, or return it where the host forbids compiling code from\n")
+	buf.WriteString("// strings.\n\n")
+
+	buf.WriteString("type PdaErrorCode =\n")
+	writeUnion(&buf, b.ErrorCodes)
+	buf.WriteString("\n// Seed types of pda.ParseTypedSeed; integers are little-endian unless\n")
+	buf.WriteString("// the type ends in \"be\"\n")
+	buf.WriteString("type TypedSeedKind =\n")
+	writeUnion(&buf, b.SeedKinds)
+
+	for _, d := range b.Decls {
+		buf.WriteString("\n" + d + "\n")
+	}
+
+	for _, iface := range b.Interfaces {
+		buf.WriteString("\ninterface " + iface.Name + " {\n")
+		for i, m := range iface.Methods {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			if m.Doc != "" {
+				buf.WriteString("  /**\n")
+				for _, l := range wrap(m.Doc, 72) {
+					buf.WriteString("   * " + l + "\n")
+				}
+				buf.WriteString("   */\n")
+			}
+			for _, sig := range m.Signatures {
+				for _, l := range strings.Split(sig, "\n") {
+					buf.WriteString("  " + l + "\n")
+				}
+			}
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.Bytes()
+}
+
+// writeUnion writes values as the members of a union of string literals
+func writeUnion(buf *bytes.Buffer, values []string) {
+	for i, v := range values {
+		fmt.Fprintf(buf, "  | %s", strconv.Quote(v))
+		if i == len(values)-1 {
+			buf.WriteString(";")
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// wrap breaks text into lines of at most width bytes, where words allow
+func wrap(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, w := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(w) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	b, err := Load("../../pkg/wasmbridge", "../../pkg/pda")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	out := string(Generate(b))
+	for _, want := range []string{
+		"// Code generated by pdatypes. DO NOT EDIT.",
+		`  | "PDA_ERR_NON_CANONICAL_BUMP"`,
+		`  | "u64be"`,
+		"interface SolanaPda {",
+		"  find(programId: AddressInput, seeds: SeedInput[], options?: PdaOptions): PdaResult;",
+		"   * Derives the canonical PDA.",
+		"  dispose(): void;",
+		"interface SolanaPdaPrograms {",
+		"  getAssociatedTokenAddress(owner: AddressInput, mint: AddressInput, options?: AtaOptions): PdaResult;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated types missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// The checked-in definitions must match the bridge
+func TestGenerate_UpToDate(t *testing.T) {
+	b, err := Load("../../pkg/wasmbridge", "../../pkg/pda")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	got, err := os.ReadFile("../../fryan-raccoon/pda.d.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, Generate(b)) {
+		t.Error("fryan-raccoon/pda.d.ts is stale; run go generate ./cmd/pdatypes")
+	}
+}
+
+const testPDA = `package pda
+
+type ErrorCode string
+
+const CodeUnknown ErrorCode = "PDA_ERR_UNKNOWN"
+
+func ParseTypedSeed(kind, value string) ([]byte, error) {
+	switch kind {
+	case "u8":
+	}
+	return nil, nil
+}
+`
+
+func TestLoad_Errors(t *testing.T) {
+	tests := map[string]string{
+		"no signature": `package main
+
+func findJS() {}
+
+func Register() { set("find", findJS) }
+`,
+		"undeclared key": `package main
+
+// findJS derives a PDA.
+//
+// TypeScript:
+//
+//	type Options = { normalize?: string };
+//	find(options?: Options): string;
+func findJS() { get(o, "normalize"); get(o, "encoding") }
+
+func Register() { set("find", findJS) }
+`,
+		"signature outside bridge": `package main
+
+// helper
+//
+// TypeScript:
+//
+//	find(): void;
+func helper() {}
+
+// findJS derives a PDA.
+//
+// TypeScript:
+//
+//	find(): string;
+func findJS() {}
+
+func Register() { set("find", findJS) }
+`,
+		"wrong method": `package main
+
+// findJS derives a PDA.
+//
+// TypeScript:
+//
+//	create(): string;
+func findJS() {}
+
+func Register() { set("find", findJS) }
+`,
+	}
+
+	pdaDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pdaDir, "pda.go"), []byte(testPDA), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, src := range tests {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(dir, pdaDir); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
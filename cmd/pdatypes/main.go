@@ -0,0 +1,43 @@
+// Command pdatypes generates the TypeScript definitions of the WASM
+// bridge's JavaScript surface from its Go source, so the types cannot drift
+// from what the bridge accepts and returns:
+//
+//	go run ./cmd/pdatypes -bridge pkg/wasmbridge -pda pkg/pda -out fryan-raccoon/pda.d.ts
+//
+// The bridge documents its functions with a "TypeScript:" block in their
+// doc comments; see Load for what is read and checked.
+package main
+
+//go:generate go run . -bridge ../../pkg/wasmbridge -pda ../../pkg/pda -out ../../fryan-raccoon/pda.d.ts
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	bridge := flag.String("bridge", "pkg/wasmbridge", "directory of the bridge's Go source")
+	pdaDir := flag.String("pda", "pkg/pda", "directory of the pda package's Go source")
+	out := flag.String("out", "", "path of the generated .d.ts file (default stdout)")
+	flag.Parse()
+
+	if err := run(*bridge, *pdaDir, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "pdatypes: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(bridge, pdaDir, out string) error {
+	b, err := Load(bridge, pdaDir)
+	if err != nil {
+		return err
+	}
+
+	src := Generate(b)
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -0,0 +1,59 @@
+//go:build js && wasm
+
+// Command wasm builds the bridge in pkg/wasmbridge as a standalone module:
+//
+//	GOOS=js GOARCH=wasm go build -o main.wasm ./cmd/wasm
+//
+// The functions are registered under globalThis.solanaPda unless
+// PDA_NAMESPACE names another global. With PDA_MODE=worker (or frame) the
+// module also serves the message protocol (see pda-worker.js and
+// pda-frame.html), to the windows whose origins PDA_ORIGINS lists
+// (comma-separated); frame mode requires it. Settings are read from go.env, or from
+// globalThis for TinyGo's loader.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall/js"
+
+	"raccoon-wasm/pkg/wasmbridge"
+)
+
+// setting returns the configuration value name from the environment
+// (go.env) or, failing that, from globalThis[name]: TinyGo's wasm_exec.js
+// does not pass go.env to the module
+func setting(name string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	if v := js.Global().Get(name); v.Type() == js.TypeString {
+		return v.String()
+	}
+	return ""
+}
+
+func main() {
+	namespace := setting("PDA_NAMESPACE")
+	if namespace == "" {
+		namespace = wasmbridge.DefaultNamespace
+	}
+	wasmbridge.Register(namespace)
+	switch setting("PDA_MODE") {
+	case "worker", "frame":
+		var origins []string
+		if list := setting("PDA_ORIGINS"); list != "" {
+			origins = strings.Split(list, ",")
+		}
+		if err := wasmbridge.ServeMessages(origins...); err != nil {
+			// The functions remain usable through the namespace
+			fmt.Fprintln(os.Stderr, "pda:", err)
+		}
+	}
+	wasmbridge.SignalReady()
+
+	// Block until JS calls stop; returning lets the Go program exit and
+	// resolves the promise returned by go.run
+	wasmbridge.Wait()
+}
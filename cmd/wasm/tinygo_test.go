@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// tinygoMaxSize is the budget the readme quotes for the TinyGo build.
+const tinygoMaxSize = 500 << 10
+
+// TestTinyGoBuild_Size builds the bridge with TinyGo, using the flags the
+// readme documents, and reports the module size. It is skipped where TinyGo
+// is not installed; run it with -v to see the size.
+func TestTinyGoBuild_Size(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TinyGo build in short mode")
+	}
+	tinygo, err := exec.LookPath("tinygo")
+	if err != nil {
+		t.Skip("tinygo not found in PATH")
+	}
+
+	out := filepath.Join(t.TempDir(), "main.wasm")
+	cmd := exec.Command(tinygo, "build", "-o", out, "-target", "wasm", "-no-debug", "-opt", "z", ".")
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("tinygo build failed: %v\n%s", err, b)
+	}
+	fi, err := os.Stat(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("TinyGo module: %d bytes", fi.Size())
+	if fi.Size() >= tinygoMaxSize {
+		t.Errorf("TinyGo module is %d bytes, want under %d", fi.Size(), tinygoMaxSize)
+	}
+}
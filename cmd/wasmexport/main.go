@@ -0,0 +1,188 @@
+//go:build wasip1
+
+// Command wasmexport builds the PDA functions as plain WebAssembly exports
+// (go:wasmexport) for hosts without a JavaScript bridge: wasmtime, wasmer,
+// Node's WASI, or a browser with a WASI shim. Build it as a reactor, so the
+// exports stay callable after initialization:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o pda.wasm ./cmd/wasmexport
+//
+// The host calls _initialize once, then exchanges data through the
+// module's memory: pda_alloc reserves a buffer the host writes arguments
+// into, and results are written to buffers the host passes in. Addresses
+// are 32 raw bytes. A seed list is encoded as each seed's length (one
+// byte) followed by its bytes. Functions return 0 on success or a
+// negative error code (see codes); pda_last_error describes the failure.
+package main
+
+import (
+	"errors"
+	"unsafe"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// codes are the failures reported to the host: the error code of a failed
+// call is -(i+1) for codes[i]
+var codes = []pda.ErrorCode{
+	pda.CodeInvalidArgument,
+	pda.CodeMaxSeeds,
+	pda.CodeSeedTooLong,
+	pda.CodeMaxSeedLength,
+	pda.CodeOnCurve,
+	pda.CodeBadBase58,
+	pda.CodeBadLength,
+	pda.CodeNoViableBump,
+	pda.CodeIllegalOwner,
+	pda.CodeUnknown,
+}
+
+// lastErr is the error of the last failed call, for pda_last_error
+var lastErr error
+
+// fail records err and returns its code. A malformed seed list is an
+// invalid argument.
+func fail(err error) int32 {
+	lastErr = err
+	code := pda.ErrorCodeOf(err)
+	if errors.Is(err, errBadSeeds) {
+		code = pda.CodeInvalidArgument
+	}
+	for i, c := range codes {
+		if c == code {
+			return -int32(i + 1)
+		}
+	}
+	return -int32(len(codes))
+}
+
+var errBadSeeds = errors.New("malformed seed list")
+
+// allocations keeps the buffers handed to the host reachable until freed
+var allocations = map[unsafe.Pointer][]byte{}
+
+// pda_alloc returns a buffer of size bytes in the module's memory, which
+// stays valid until passed to pda_free
+//
+//go:wasmexport pda_alloc
+func alloc(size uint32) unsafe.Pointer {
+	if size == 0 {
+		size = 1
+	}
+	buf := make([]byte, size)
+	ptr := unsafe.Pointer(&buf[0])
+	allocations[ptr] = buf
+	return ptr
+}
+
+// pda_free releases a buffer returned by pda_alloc
+//
+//go:wasmexport pda_free
+func free(ptr unsafe.Pointer) {
+	delete(allocations, ptr)
+}
+
+// pda_last_error copies the message of the last failure into out, up to
+// size bytes, and returns the message's full length
+//
+//go:wasmexport pda_last_error
+func lastError(out unsafe.Pointer, size uint32) uint32 {
+	if lastErr == nil {
+		return 0
+	}
+	msg := lastErr.Error()
+	copy(unsafe.Slice((*byte)(out), size), msg)
+	return uint32(len(msg))
+}
+
+// seeds is scratch reused across calls; the host calls in one at a time
+var seeds [][]byte
+
+// readSeeds decodes the seed list of n bytes at ptr. The seeds alias the
+// host's buffer.
+func readSeeds(ptr unsafe.Pointer, n uint32) ([][]byte, error) {
+	seeds = seeds[:0]
+	if n == 0 {
+		return seeds, nil
+	}
+	b := unsafe.Slice((*byte)(ptr), n)
+	for len(b) > 0 {
+		size := int(b[0])
+		if len(b) < 1+size {
+			return nil, errBadSeeds
+		}
+		seeds = append(seeds, b[1:1+size])
+		b = b[1+size:]
+	}
+	return seeds, nil
+}
+
+// address returns the 32 bytes at ptr
+func address(ptr unsafe.Pointer) *[32]byte {
+	return (*[32]byte)(ptr)
+}
+
+// pda_find derives the canonical PDA for the seed list under program and
+// writes the address followed by the bump (33 bytes) to out
+//
+//go:wasmexport pda_find
+func find(program, seedList unsafe.Pointer, seedListLen uint32, out unsafe.Pointer) int32 {
+	s, err := readSeeds(seedList, seedListLen)
+	if err != nil {
+		return fail(err)
+	}
+	result := unsafe.Slice((*byte)(out), 33)
+	bump, err := pda.FindPDAInto((*[32]byte)(result[:32]), *address(program), s)
+	if err != nil {
+		return fail(err)
+	}
+	result[32] = bump
+	return 0
+}
+
+// pda_create derives the address for the seed list used as-is, bump
+// included, and writes it to out
+//
+//go:wasmexport pda_create
+func create(program, seedList unsafe.Pointer, seedListLen uint32, out unsafe.Pointer) int32 {
+	s, err := readSeeds(seedList, seedListLen)
+	if err != nil {
+		return fail(err)
+	}
+	addr, err := pda.CreateProgramDerivedAddress(pda.ProgramDerivedAddressInput{ProgramAddress: *address(program), Seeds: s})
+	if err != nil {
+		return fail(err)
+	}
+	*address(out) = addr
+	return 0
+}
+
+// pda_create_with_seed writes the address of an account created with
+// CreateAccountWithSeed, sha256(base || seed || owner), to out
+//
+//go:wasmexport pda_create_with_seed
+func createWithSeed(base, seed unsafe.Pointer, seedLen uint32, owner, out unsafe.Pointer) int32 {
+	var s string
+	if seedLen > 0 {
+		s = string(unsafe.Slice((*byte)(seed), seedLen))
+	}
+	addr, err := pda.CreateWithSeed(*address(base), s, *address(owner))
+	if err != nil {
+		return fail(err)
+	}
+	*address(out) = addr
+	return 0
+}
+
+// pda_is_on_curve returns 1 if the address is a valid ed25519 point (a
+// keypair address rather than a PDA), else 0
+//
+//go:wasmexport pda_is_on_curve
+func isOnCurve(addr unsafe.Pointer) int32 {
+	if onCurve, _ := pda.Address(*address(addr)).IsOnCurve(); onCurve {
+		return 1
+	}
+	return 0
+}
+
+func main() {}
@@ -0,0 +1,150 @@
+//go:build wasip1
+
+// The export tests run under a WASI runtime through the Go toolchain's
+// runner (wasmtime by default; see GOWASIRUNTIME):
+//
+//	PATH="$(go env GOROOT)/lib/wasm:$PATH" GOOS=wasip1 GOARCH=wasm go test ./cmd/wasmexport
+package main
+
+import (
+	"bytes"
+	"testing"
+	"unsafe"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// hostBuf copies b into a buffer from pda_alloc, as a host would, and
+// frees it when the test ends
+func hostBuf(t *testing.T, b []byte) unsafe.Pointer {
+	ptr := alloc(uint32(len(b)))
+	copy(unsafe.Slice((*byte)(ptr), len(b)), b)
+	t.Cleanup(func() { free(ptr) })
+	return ptr
+}
+
+// seedList encodes seeds as pda_find and pda_create read them
+func seedList(seeds ...[]byte) []byte {
+	var b []byte
+	for _, s := range seeds {
+		b = append(b, byte(len(s)))
+		b = append(b, s...)
+	}
+	return b
+}
+
+// errorMessage returns pda_last_error's message
+func errorMessage() string {
+	buf := make([]byte, 256)
+	n := lastError(unsafe.Pointer(&buf[0]), uint32(len(buf)))
+	return string(buf[:min(n, uint32(len(buf)))])
+}
+
+var program = pda.Address{1, 2, 3}
+
+func TestFind(t *testing.T) {
+	seeds := [][]byte{[]byte("vault"), {}, {0xff, 0x00}}
+	list := seedList(seeds...)
+	out := make([]byte, 33)
+
+	if code := find(hostBuf(t, program[:]), hostBuf(t, list), uint32(len(list)), unsafe.Pointer(&out[0])); code != 0 {
+		t.Fatalf("pda_find = %d (%s)", code, errorMessage())
+	}
+
+	want, err := pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out[:32], want.Address[:]) || out[32] != want.Bump {
+		t.Errorf("pda_find = %x/%d, want %s/%d", out[:32], out[32], want.Address, want.Bump)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	want, err := pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{ProgramAddress: program, Seeds: [][]byte{[]byte("vault")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := seedList([]byte("vault"), []byte{want.Bump})
+	var out pda.Address
+
+	if code := create(hostBuf(t, program[:]), hostBuf(t, list), uint32(len(list)), unsafe.Pointer(&out)); code != 0 {
+		t.Fatalf("pda_create = %d (%s)", code, errorMessage())
+	}
+	if out != want.Address {
+		t.Errorf("pda_create = %s, want %s", out, want.Address)
+	}
+}
+
+func TestCreateWithSeed(t *testing.T) {
+	base, owner := pda.Address{7}, pda.Address{8}
+	var out pda.Address
+
+	seed := []byte("stake:0")
+	if code := createWithSeed(hostBuf(t, base[:]), hostBuf(t, seed), uint32(len(seed)), hostBuf(t, owner[:]), unsafe.Pointer(&out)); code != 0 {
+		t.Fatalf("pda_create_with_seed = %d (%s)", code, errorMessage())
+	}
+	want, err := pda.CreateWithSeed(base, string(seed), owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != want {
+		t.Errorf("pda_create_with_seed = %s, want %s", out, want)
+	}
+}
+
+func TestIsOnCurve(t *testing.T) {
+	out, err := pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{ProgramAddress: program})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := isOnCurve(hostBuf(t, out.Address[:])); got != 0 {
+		t.Errorf("pda_is_on_curve(PDA) = %d, want 0", got)
+	}
+	// The ed25519 base point's encoding
+	base := pda.Address{0x58, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66,
+		0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66}
+	if got := isOnCurve(hostBuf(t, base[:])); got != 1 {
+		t.Errorf("pda_is_on_curve(base point) = %d, want 1", got)
+	}
+}
+
+func TestFind_Errors(t *testing.T) {
+	tooMany := make([][]byte, pda.MaxSeeds)
+	tests := []struct {
+		name string
+		list []byte
+		code pda.ErrorCode
+	}{
+		{"truncated seed", []byte{3, 'a'}, pda.CodeInvalidArgument},
+		{"seed too long", seedList([]byte("a"), bytes.Repeat([]byte("b"), pda.MaxSeedLength+1)), pda.CodeSeedTooLong},
+		{"too many seeds", seedList(tooMany...), pda.CodeMaxSeeds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := make([]byte, 33)
+			code := find(hostBuf(t, program[:]), hostBuf(t, tt.list), uint32(len(tt.list)), unsafe.Pointer(&out[0]))
+			if code >= 0 {
+				t.Fatalf("pda_find = %d, want an error", code)
+			}
+			if got := codes[-code-1]; got != tt.code {
+				t.Errorf("pda_find code %d = %s, want %s", code, got, tt.code)
+			}
+			if msg := errorMessage(); msg == "" {
+				t.Error("pda_last_error is empty")
+			}
+		})
+	}
+}
+
+func TestLastError_Truncates(t *testing.T) {
+	lastErr = errBadSeeds
+	buf := make([]byte, 4)
+	if n := lastError(unsafe.Pointer(&buf[0]), uint32(len(buf))); int(n) != len(errBadSeeds.Error()) {
+		t.Errorf("pda_last_error = %d, want the full length %d", n, len(errBadSeeds.Error()))
+	}
+	if string(buf) != errBadSeeds.Error()[:4] {
+		t.Errorf("pda_last_error wrote %q", buf)
+	}
+}
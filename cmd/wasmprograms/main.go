@@ -0,0 +1,34 @@
+//go:build js && wasm
+
+// Command wasmprograms builds the protocol helpers (package programs) as a
+// secondary module, so the core module stays small for pages that only
+// derive PDAs and the helpers are fetched when a page first needs them:
+//
+//	GOOS=js GOARCH=wasm go build -o programs.wasm ./cmd/wasmprograms
+//
+// It is loaded like the core module, as a separate instance with its own
+// Go object, and registers its functions under globalThis.solanaPdaPrograms
+// unless PDA_NAMESPACE (in go.env, or on globalThis for TinyGo's loader)
+// names another global. The namespace is set by the time go.run returns.
+package main
+
+import (
+	"os"
+	"syscall/js"
+
+	"raccoon-wasm/pkg/wasmbridge"
+)
+
+func main() {
+	namespace := os.Getenv("PDA_NAMESPACE")
+	if v := js.Global().Get("PDA_NAMESPACE"); namespace == "" && v.Type() == js.TypeString {
+		namespace = v.String()
+	}
+	if namespace == "" {
+		namespace = wasmbridge.DefaultProgramsNamespace
+	}
+	wasmbridge.RegisterPrograms(namespace)
+
+	// Block until JS calls stop
+	wasmbridge.Wait()
+}
@@ -0,0 +1,268 @@
+// Code generated by pdatypes. DO NOT EDIT.
+
+// The functions the Go bridge (pkg/wasmbridge) registers on its namespace
+// object, globalThis.solanaPda by default, and those of the protocol helpers
+// module (cmd/wasmprograms) on globalThis.solanaPdaPrograms. Failures throw
+// a PdaError, or return it where the host forbids compiling code from
+// strings.
+
+type PdaErrorCode =
+  | "PDA_ERR_MAX_SEEDS"
+  | "PDA_ERR_SEED_TOO_LONG"
+  | "PDA_ERR_MAX_SEED_LENGTH_EXCEEDED"
+  | "PDA_ERR_ON_CURVE"
+  | "PDA_ERR_BAD_BASE58"
+  | "PDA_ERR_BAD_LENGTH"
+  | "PDA_ERR_NO_VIABLE_BUMP"
+  | "PDA_ERR_BAD_SEED_SPEC"
+  | "PDA_ERR_ILLEGAL_OWNER"
+  | "PDA_ERR_NON_CANONICAL_BUMP"
+  | "PDA_ERR_CANCELED"
+  | "PDA_ERR_INVALID_ARGUMENT"
+  | "PDA_ERR_UNKNOWN";
+
+// Seed types of pda.ParseTypedSeed; integers are little-endian unless
+// the type ends in "be"
+type TypedSeedKind =
+  | "str"
+  | "string"
+  | "nfc"
+  | "nfkc"
+  | "u8"
+  | "u16"
+  | "u16le"
+  | "u16be"
+  | "u32"
+  | "u32le"
+  | "u32be"
+  | "u64"
+  | "u64le"
+  | "u64be"
+  | "u128"
+  | "u128le"
+  | "u128be"
+  | "pubkey"
+  | "hex"
+  | "base58"
+  | "base64";
+
+type TypedSeed = { type: TypedSeedKind; value: string | number | bigint };
+
+type NestedBytes = (number | NestedBytes)[];
+
+type AddressInput = string | Uint8Array;
+
+type PdaOptions = {
+  normalize?: "none" | "NFC" | "NFKC";
+  // How String seeds become bytes; "prefixed" decodes "hex:..." and
+  // "base64:..." seeds and treats other strings as UTF-8
+  seedEncoding?: "utf8" | "hex" | "base64" | "prefixed";
+  // Encoding of result addresses (default base58)
+  encoding?: "base58" | "hex" | "base64";
+  // Add addressBytes (the raw 32 bytes) to results
+  includeBytes?: boolean;
+  // create: fail with PDA_ERR_NON_CANONICAL_BUMP unless the last seed is
+  // the canonical bump
+  commitmentToCanonicalBump?: boolean;
+  // Given one, getProgramDerivedAddresses, deriveRange and grind run
+  // asynchronously and return a Promise, which an abort rejects
+  abortSignal?: AbortSignal;
+  // getProgramDerivedAddresses packs result i into output at i*33
+  // (32-byte address, then the bump) and returns only the failures
+  output?: SharedArrayBuffer | ArrayBuffer | ArrayBufferView;
+};
+
+type Abortable = PdaOptions & { abortSignal: AbortSignal };
+
+type PackedOptions = PdaOptions & { output: SharedArrayBuffer | ArrayBuffer | ArrayBufferView };
+
+type AddressResult = { address: string; addressBytes?: Uint8Array };
+
+interface PdaError extends Error {
+  code: PdaErrorCode;
+  // Set when the error concerns a single seed
+  seedIndex?: number;
+  // The request or range index of a failed item in a batch result
+  index?: RangeIndex;
+}
+
+// Number arrays must hold integers 0-255 and may be nested; views
+// (TypedArrays, DataView) contribute their raw bytes
+type SeedInput = string | ArrayBuffer | ArrayBufferView | NestedBytes | TypedSeed;
+
+type PdaResult = AddressResult & { bump: number; normalized?: number[] };
+
+type PdaBatchRequest = { programId: AddressInput; seeds: SeedInput[] };
+
+type PdaBuild = {
+  version: string;
+  commit?: string;
+  modified?: boolean;
+  goVersion: string;
+  marker: string;
+  maxSeeds: number;
+  maxSeedLength: number;
+};
+
+type ProgressOptions = { onProgress?: (done: number, rate: number) => void; progressIntervalMs?: number };
+
+type GrindOptions = ProgressOptions & { prefix?: string; suffix?: string };
+
+type GrindResult = AddressResult & { bump: number; seed: Uint8Array };
+
+type RangeIndex = number | bigint | string;
+
+type RangeResult = AddressResult & { index: RangeIndex; bump: number };
+
+type AtaOptions = PdaOptions & { tokenProgram?: AddressInput };
+
+type PdaWorkerRequest =
+  | { id: unknown; method: keyof SolanaPda; params?: unknown[]; args?: unknown[] }
+  | { id: unknown; cancel: true };
+
+type PdaWorkerReply =
+  | { ready: true }
+  | { id: unknown; result: unknown }
+  | { id: unknown; error: { message: string; code: PdaErrorCode; seedIndex?: number; index?: RangeIndex } }
+  | { id: unknown; progress: { done: number; rate: number } };
+
+interface SolanaPda {
+  /**
+   * Derives the canonical PDA.
+   */
+  find(programId: AddressInput, seeds: SeedInput[], options?: PdaOptions): PdaResult;
+
+  /**
+   * Derives a PDA for every {programId, seeds} request in one call,
+   * returning {address, bump} or an Error per item, so one bad request does
+   * not fail the others. With an abortSignal it returns a Promise.
+   */
+  getProgramDerivedAddresses(requests: PdaBatchRequest[], options: PackedOptions & Abortable): Promise<PdaError[]>;
+  getProgramDerivedAddresses(requests: PdaBatchRequest[], options: PackedOptions): PdaError[];
+  getProgramDerivedAddresses(requests: PdaBatchRequest[], options: Abortable): Promise<(PdaResult | PdaError)[]>;
+  getProgramDerivedAddresses(requests: PdaBatchRequest[], options?: PdaOptions): (PdaResult | PdaError)[];
+
+  /**
+   * Derives the address for seeds used as-is, bump included.
+   */
+  create(programId: AddressInput, seeds: SeedInput[], options?: PdaOptions): AddressResult & { normalized?: number[] };
+
+  /**
+   * Reports whether a base58 address is a valid ed25519 point (a keypair
+   * address rather than a PDA).
+   */
+  isOnCurve(address: AddressInput): boolean;
+
+  /**
+   * Returns every bump, 255 down to 0, that yields an off-curve address; the
+   * first is the canonical bump.
+   */
+  getAllValidBumps(programId: AddressInput, seeds: SeedInput[], options?: PdaOptions): number[];
+
+  /**
+   * Derives the address of an account created with CreateAccountWithSeed,
+   * sha256(base || seed || owner).
+   */
+  createAddressWithSeed(base: AddressInput, seed: string, owner: AddressInput, options?: PdaOptions): AddressResult;
+
+  /**
+   * Searches for a PDA whose address matches {prefix, suffix} by appending
+   * an incrementing u64 seed to the fixed seeds. With an abortSignal it
+   * returns a Promise.
+   */
+  grind(programId: AddressInput, fixedSeeds: SeedInput[], options: Abortable & GrindOptions): Promise<GrindResult>;
+  grind(programId: AddressInput, fixedSeeds: SeedInput[], options: PdaOptions & GrindOptions): GrindResult;
+
+  /**
+   * Derives the PDA for prefixSeeds + u64le(index) for every index in
+   * [start, end), returning {index, address, bump} per index. Indices past
+   * 2^53 - 1 are returned as BigInts, or as decimal Strings if the range was
+   * given as Strings, since a Number would round them. With an abortSignal
+   * it returns a Promise.
+   */
+  deriveRange(programId: AddressInput, prefixSeeds: SeedInput[], start: RangeIndex, end: RangeIndex, options: Abortable): Promise<(RangeResult | PdaError)[]>;
+  deriveRange(programId: AddressInput, prefixSeeds: SeedInput[], start: RangeIndex, end: RangeIndex, options?: PdaOptions): (RangeResult | PdaError)[];
+
+  /**
+   * Encodes bytes of any length as base58.
+   */
+  encodeBase58(bytes: Uint8Array): string;
+
+  /**
+   * Decodes a base58 string of any length to bytes.
+   */
+  decodeBase58(text: string): Uint8Array;
+
+  /**
+   * Identifies the build: module version, VCS commit and the marker and
+   * limits the bridge derives with.
+   */
+  pdaVersion(): PdaBuild;
+
+  /**
+   * Removes the namespace, releases every callback and cancels calls still
+   * running (their Promises reject with PDA_ERR_CANCELED), then lets the Go
+   * program exit, resolving the promise returned by go.run.
+   */
+  stop(): void;
+
+  /**
+   * Is the former name of stop, kept for existing callers.
+   */
+  dispose(): void;
+}
+
+interface SolanaPdaPrograms {
+  /**
+   * Derives the associated token account of owner for mint. The mint is
+   * taken to belong to the classic Token program unless options.tokenProgram
+   * names another, such as Token-2022.
+   */
+  getAssociatedTokenAddress(owner: AddressInput, mint: AddressInput, options?: AtaOptions): PdaResult;
+
+  /**
+   * Derives the associated token account of owner for a mint of the
+   * Token-2022 program.
+   */
+  getAssociatedTokenAddress2022(owner: AddressInput, mint: AddressInput, options?: PdaOptions): PdaResult;
+
+  /**
+   * Derives the Metaplex metadata account of a mint.
+   */
+  findMetadataPda(mint: AddressInput, options?: PdaOptions): PdaResult;
+
+  /**
+   * Derives the Metaplex master edition account of a mint.
+   */
+  findMasterEditionPda(mint: AddressInput, options?: PdaOptions): PdaResult;
+
+  /**
+   * Derives the Metaplex edition marker account that records whether an
+   * edition of a master edition mint was printed.
+   */
+  findEditionMarkerPda(mint: AddressInput, edition: RangeIndex, options?: PdaOptions): PdaResult;
+
+  /**
+   * Derives the authority PDA of a Candy Machine v3, which mint instructions
+   * take as an account.
+   */
+  findCandyMachineAuthorityPda(candyMachine: AddressInput, options?: PdaOptions): PdaResult;
+
+  /**
+   * Derives the candy guard account created from base.
+   */
+  findCandyGuardPda(base: AddressInput, options?: PdaOptions): PdaResult;
+
+  /**
+   * Identifies the build: module version, VCS commit and the marker and
+   * limits the bridge derives with.
+   */
+  pdaVersion(): PdaBuild;
+
+  /**
+   * Removes the namespace, releases every callback and cancels calls still
+   * running (their Promises reject with PDA_ERR_CANCELED), then lets the Go
+   * program exit, resolving the promise returned by go.run.
+   */
+  stop(): void;
+}
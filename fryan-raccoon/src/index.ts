@@ -5,7 +5,8 @@ import wasmModule from "./main.wasm";
 // Define the interface for the expected JSON body
 interface PdaRequest {
 	programId: string;
-	seeds: (string | number[])[]; // JSON arrays are number[], we need to cast to Uint8Array later
+	seeds: (string | number[])[]; // JSON arrays are number[], which the bridge accepts as byte seeds
+	normalize?: "none" | "NFC" | "NFKC"; // Unicode normalization for string seeds
 }
 
 const go = new Go();
@@ -16,10 +17,25 @@ async function initWasm() {
 		// WebAssembly.instantiate in Cloudflare Workers takes the Module directly
 		instance = await WebAssembly.instantiate(wasmModule, go.importObject);
 		go.run(instance);
+		// Resolved by the Go side once its functions are registered
+		await globalThis.__pdaReady;
 	}
 }
 
 
+// The bridge throws Errors carrying a code; where the host forbids
+// compiling code (as Workers do) it returns the Error instead
+function derive(programId: string, seeds: PdaRequest["seeds"], normalize: PdaRequest["normalize"]) {
+	try {
+		return globalThis.solanaPda.find(programId, seeds, { normalize });
+	} catch (err) {
+		if (err instanceof Error && "code" in err) {
+			return err as PdaError;
+		}
+		throw err;
+	}
+}
+
 export default {
 	async fetch(request, env, ctx): Promise<Response> {
 		await initWasm();
@@ -28,25 +44,18 @@ export default {
 			try {
 				// Cast the parsed JSON to our interface
 				const body = await request.json() as PdaRequest;
-				const { programId, seeds } = body;
+				const { programId, seeds, normalize } = body;
 
 				if (!programId || !seeds) {
 					return new Response("Missing programId or seeds", { status: 400 });
 				}
 
-				// Transform JSON arrays into Uint8Arrays for the Go bridge
-				const processedSeeds = seeds.map((s) => {
-					if (Array.isArray(s)) {
-						return new Uint8Array(s);
-					}
-					return s;
-				});
-
-				// Call the global function (now typed in types.d.ts)
-				const result = globalThis.getProgramDerivedAddress(programId, processedSeeds);
+				// Call the bridge's namespaced function (typed in types.d.ts)
+				const result = derive(programId, seeds, normalize);
 
-				if (result.error) {
-					return new Response(JSON.stringify(result), {
+				if (result instanceof Error) {
+					const { message, code, seedIndex } = result;
+					return new Response(JSON.stringify({ error: message, code, seedIndex }), {
 						status: 400,
 						headers: { "Content-Type": "application/json" },
 					});
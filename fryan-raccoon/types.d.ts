@@ -1,4 +1,5 @@
 // src/types.d.ts
+/// <reference path="./pda.d.ts" />
 
 // 1. Fix "Cannot find module './main.wasm'"
 // This tells TS that .wasm files export a WebAssembly.Module
@@ -18,13 +19,20 @@ declare class Go {
   run(instance: WebAssembly.Instance): Promise<void>;
 }
 
-// 3. Define your custom Go function on the Global scope
-// This allows you to call window.getProgramDerivedAddress() without TS errors
+// 3. The bridge's functions and their argument, result and error types are
+// generated from the Go code into pda.d.ts (go generate ./cmd/pdatypes).
+// The bridge adds a single namespace object, globalThis.solanaPda by
+// default (set go.env.PDA_NAMESPACE before go.run to rename it)
 declare global {
-  function getProgramDerivedAddress(
-    programId: string, 
-    seeds: (string | Uint8Array)[]
-  ): { address: string; bump: number; error?: string };
+  // Set to a resolved Promise once the Go functions are registered; a
+  // "pda-ready" event is also dispatched on globalThis where supported
+  var __pdaReady: Promise<void> | undefined;
+
+  var solanaPda: SolanaPda;
+
+  // Set by the optional protocol helpers module (cmd/wasmprograms), loaded
+  // as a second Go instance
+  var solanaPdaPrograms: SolanaPdaPrograms | undefined;
 }
 
 export {};
\ No newline at end of file
@@ -0,0 +1,32 @@
+// Runs the PDA module in a Web Worker, so heavy calls such as grind don't
+// block the page:
+//
+//   const worker = new Worker("pda-worker.js");
+//   worker.postMessage({ id: 1, method: "grind", params: [programId, ["vanity"], { prefix: "abc", onProgress: true }] });
+//   worker.postMessage({ id: 1, cancel: true }); // changed your mind
+//
+// The worker answers {ready: true} once loaded, then {id, result} or
+// {id, error: {message, code, seedIndex}} per call, plus
+// {id, progress: {done, rate}} while a grind with onProgress: true runs.
+// The protocol is implemented by the Go bridge (pkg/wasmbridge/worker.go).
+// wasm_exec.js must come from the Go toolchain that built main.wasm.
+importScripts("wasm_exec.js");
+
+// Messages that arrive while the module loads are replayed once it is ready
+const queued = [];
+const queue = (event) => queued.push(event.data);
+self.addEventListener("message", queue);
+
+const go = new Go();
+go.env = { PDA_MODE: "worker" };
+
+WebAssembly.instantiateStreaming(fetch("main.wasm"), go.importObject).then((result) => {
+    // go.run registers the message handler before it returns
+    go.run(result.instance);
+    self.removeEventListener("message", queue);
+    for (const data of queued) {
+        self.dispatchEvent(new MessageEvent("message", { data }));
+    }
+}).catch((err) => {
+    self.postMessage({ error: { message: `Failed to load WASM: ${err}` } });
+});
@@ -0,0 +1,68 @@
+package pda
+
+import (
+	"bytes"
+	"slices"
+)
+
+// Compare orders addresses by their raw bytes, returning -1, 0 or +1. This
+// is the same order Solana uses when sorting Pubkeys.
+func Compare(a, b Address) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// SortAddresses sorts addrs in place using Compare
+func SortAddresses(addrs []Address) {
+	slices.SortFunc(addrs, Compare)
+}
+
+// AddressSet is a set of addresses. The zero value is not usable; create
+// one with NewAddressSet.
+type AddressSet map[Address]struct{}
+
+// NewAddressSet creates a set containing addrs
+func NewAddressSet(addrs ...Address) AddressSet {
+	s := make(AddressSet, len(addrs))
+	s.Add(addrs...)
+	return s
+}
+
+// Add inserts addrs into the set
+func (s AddressSet) Add(addrs ...Address) {
+	for _, a := range addrs {
+		s[a] = struct{}{}
+	}
+}
+
+// Contains reports whether a is in the set
+func (s AddressSet) Contains(a Address) bool {
+	_, ok := s[a]
+	return ok
+}
+
+// Len returns the number of addresses in the set
+func (s AddressSet) Len() int {
+	return len(s)
+}
+
+// Union returns a new set with the addresses of both s and other
+func (s AddressSet) Union(other AddressSet) AddressSet {
+	u := make(AddressSet, len(s)+len(other))
+	for a := range s {
+		u[a] = struct{}{}
+	}
+	for a := range other {
+		u[a] = struct{}{}
+	}
+	return u
+}
+
+// Sorted returns the addresses in deterministic (Compare) order
+func (s AddressSet) Sorted() []Address {
+	addrs := make([]Address, 0, len(s))
+	for a := range s {
+		addrs = append(addrs, a)
+	}
+	SortAddresses(addrs)
+	return addrs
+}
@@ -0,0 +1,48 @@
+package pda
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareAndSort(t *testing.T) {
+	token := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	loader := MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111")
+
+	if Compare(ZeroAddress, token) != -1 || Compare(token, ZeroAddress) != 1 || Compare(token, token) != 0 {
+		t.Error("unexpected Compare results")
+	}
+
+	addrs := []Address{loader, token, ZeroAddress}
+	SortAddresses(addrs)
+
+	if !slices.IsSortedFunc(addrs, Compare) || addrs[0] != ZeroAddress {
+		t.Errorf("addresses not sorted: %v", addrs)
+	}
+}
+
+func TestAddressSet(t *testing.T) {
+	token := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	loader := MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111")
+
+	a := NewAddressSet(token, token)
+	if a.Len() != 1 || !a.Contains(token) || a.Contains(loader) {
+		t.Errorf("unexpected set contents: %v", a.Sorted())
+	}
+
+	b := NewAddressSet(loader)
+	b.Add(ZeroAddress)
+
+	u := a.Union(b)
+	if u.Len() != 3 {
+		t.Errorf("expected union of 3 addresses, got %d", u.Len())
+	}
+	if a.Len() != 1 || b.Len() != 2 {
+		t.Error("Union must not modify its operands")
+	}
+
+	sorted := u.Sorted()
+	if !slices.IsSortedFunc(sorted, Compare) || len(sorted) != 3 {
+		t.Errorf("Sorted returned %v", sorted)
+	}
+}
@@ -0,0 +1,111 @@
+package pda
+
+// Allocation-free base58 for 32-byte addresses. mr-tron/base58 handles
+// arbitrary lengths but allocates scratch and result buffers on every call;
+// these write into caller-provided memory for bulk pipelines.
+
+// maxAddressLen is the longest base58 encoding of 32 bytes
+const maxAddressLen = 44
+
+// base58Index maps an ASCII character to its base58 digit, or 0xff
+var base58Index = func() (m [256]byte) {
+	for i := range m {
+		m[i] = 0xff
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		m[base58Alphabet[i]] = byte(i)
+	}
+	return m
+}()
+
+// AppendAddress appends the base58 encoding of raw to dst and returns the
+// extended slice. It allocates only if dst lacks capacity for up to 44
+// bytes.
+func AppendAddress(dst []byte, raw [32]byte) []byte {
+	zeros := 0
+	for zeros < len(raw) && raw[zeros] == 0 {
+		zeros++
+	}
+
+	// digits holds the base58 digits, least significant first
+	var digits [maxAddressLen]byte
+	size := 0
+	for _, b := range raw[zeros:] {
+		carry := uint32(b)
+		for j := 0; j < size; j++ {
+			carry += uint32(digits[j]) << 8
+			digits[j] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits[size] = byte(carry % 58)
+			size++
+			carry /= 58
+		}
+	}
+
+	for i := 0; i < zeros; i++ {
+		dst = append(dst, '1')
+	}
+	for j := size - 1; j >= 0; j-- {
+		dst = append(dst, base58Alphabet[digits[j]])
+	}
+	return dst
+}
+
+// DecodeAddressInto decodes the base58 address in src into dst without
+// allocating. Errors match DecodeAddress: ErrInvalidBase58 for characters
+// outside the alphabet (or empty input) and ErrInvalidAddressLength when the
+// decoded value is not exactly 32 bytes.
+func DecodeAddressInto(dst *[32]byte, src []byte) error {
+	return decodeAddress(dst, src)
+}
+
+// decodeAddress is shared by DecodeAddressInto and DecodeAddress so string
+// input does not need a []byte conversion
+func decodeAddress[S ~string | ~[]byte](dst *[32]byte, src S) error {
+	if len(src) == 0 {
+		return ErrInvalidBase58
+	}
+
+	zeros := 0
+	for zeros < len(src) && src[zeros] == '1' {
+		zeros++
+	}
+
+	// out holds the decoded bytes, least significant first. 64 bytes is
+	// enough for any input up to 87 characters; longer input is rejected
+	// without computing its exact length.
+	var out [64]byte
+	size := 0
+	for i := zeros; i < len(src); i++ {
+		d := base58Index[src[i]]
+		if d == 0xff {
+			return ErrInvalidBase58
+		}
+		carry := uint32(d)
+		for j := 0; j < size; j++ {
+			carry += uint32(out[j]) * 58
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			if size == len(out) {
+				return ErrInvalidAddressLength{Length: zeros + size + 1}
+			}
+			out[size] = byte(carry)
+			size++
+			carry >>= 8
+		}
+	}
+
+	if n := zeros + size; n != 32 {
+		return ErrInvalidAddressLength{Length: n}
+	}
+
+	*dst = [32]byte{}
+	for j := 0; j < size; j++ {
+		dst[31-j] = out[j]
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package pda
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/mr-tron/base58"
+)
+
+func TestAppendAddress_MatchesBase58(t *testing.T) {
+	cases := [][32]byte{{}, {1}, {31: 1}}
+	for i := 0; i < 200; i++ {
+		var b [32]byte
+		rand.Read(b[:])
+		// Exercise leading zero runs of different lengths
+		for j := 0; j < i%8; j++ {
+			b[j] = 0
+		}
+		cases = append(cases, b)
+	}
+
+	buf := make([]byte, 0, maxAddressLen)
+	for _, raw := range cases {
+		want := base58.Encode(raw[:])
+		buf = AppendAddress(buf[:0], raw)
+		if string(buf) != want {
+			t.Fatalf("%x: got %s, want %s", raw, buf, want)
+		}
+
+		var got [32]byte
+		if err := DecodeAddressInto(&got, buf); err != nil {
+			t.Fatalf("DecodeAddressInto(%s) failed: %v", buf, err)
+		}
+		if got != raw {
+			t.Fatalf("%s: decoded %x, want %x", buf, got, raw)
+		}
+	}
+}
+
+func TestAppendAddress_Appends(t *testing.T) {
+	got := AppendAddress([]byte("id="), [32]byte(MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")))
+	if string(got) != "id=TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA" {
+		t.Errorf("unexpected result: %s", got)
+	}
+}
+
+func TestDecodeAddressInto_Errors(t *testing.T) {
+	var dst [32]byte
+
+	for _, in := range []string{"", "0OIl", "Tokenkeg!"} {
+		if err := DecodeAddressInto(&dst, []byte(in)); !errors.Is(err, ErrInvalidBase58) {
+			t.Errorf("%q: expected ErrInvalidBase58, got: %v", in, err)
+		}
+	}
+
+	for _, in := range []string{"1", "abc", "11111111111111111111111111111111111", string(bytes.Repeat([]byte("z"), 200))} {
+		var lengthErr ErrInvalidAddressLength
+		if err := DecodeAddressInto(&dst, []byte(in)); !errors.As(err, &lengthErr) {
+			t.Errorf("%q: expected ErrInvalidAddressLength, got: %v", in, err)
+		}
+	}
+}
+
+func TestBase58_NoAllocations(t *testing.T) {
+	raw := [32]byte(MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"))
+	buf := make([]byte, 0, maxAddressLen)
+	var dst [32]byte
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendAddress(buf[:0], raw)
+		DecodeAddressInto(&dst, buf)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
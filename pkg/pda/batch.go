@@ -0,0 +1,101 @@
+package pda
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// BatchOption configures FindPDABatch
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	ctx      context.Context
+	workers  int
+	opts     []Option
+	progress ProgressFunc
+	interval time.Duration
+}
+
+// WithWorkers sets the number of goroutines used by FindPDABatch.
+// Values below 1 fall back to GOMAXPROCS; either way the count is limited
+// by SetMaxConcurrency.
+func WithWorkers(n int) BatchOption {
+	return func(c *batchConfig) {
+		c.workers = n
+	}
+}
+
+// WithDeriveOptions applies derivation options (marker, limits, ...) to
+// every input in the batch
+func WithDeriveOptions(opts ...Option) BatchOption {
+	return func(c *batchConfig) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+// WithBatchProgress calls fn with the number of inputs processed so far
+// every interval while FindPDABatch runs, and once more when it finishes
+func WithBatchProgress(fn ProgressFunc, interval time.Duration) BatchOption {
+	return func(c *batchConfig) {
+		c.progress = fn
+		c.interval = interval
+	}
+}
+
+// WithBatchContext stops FindPDABatch early once ctx is done; inputs not
+// derived by then get the context's cause as their error
+func WithBatchContext(ctx context.Context) BatchOption {
+	return func(c *batchConfig) {
+		c.ctx = ctx
+	}
+}
+
+// FindPDABatch derives a PDA for every input, spreading the work across a
+// pool of goroutines. Results and errors are returned in input order; for
+// each index exactly one of them is meaningful. Options are resolved and
+// every input is validated once up front, so only valid inputs reach the
+// workers.
+func FindPDABatch(inputs []ProgramDerivedAddressInput, opts ...BatchOption) ([]ProgramDerivedAddressOutput, []error) {
+	cfg := batchConfig{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	derive := newConfig(cfg.opts)
+
+	outputs := make([]ProgramDerivedAddressOutput, len(inputs))
+	errs := make([]error, len(inputs))
+
+	valid := make([]int, 0, len(inputs))
+	for i := range inputs {
+		// Validate seeds (need room for bump seed)
+		if errs[i] = validateSeeds(inputs[i].Seeds, 1, derive); errs[i] == nil {
+			valid = append(valid, i)
+		}
+	}
+
+	// Inputs rejected by validation count as processed
+	done := newProgress(cfg.progress, cfg.interval)
+	done.add(uint64(len(inputs) - len(valid)))
+
+	var next atomic.Int64
+	runWorkers(cfg.ctx, workerCount(cfg.workers, len(valid)), func(int) {
+		for cfg.ctx.Err() == nil {
+			j := int(next.Add(1) - 1)
+			if j >= len(valid) {
+				return
+			}
+			i := valid[j]
+			outputs[i], errs[i] = deriveValidated(inputs[i], derive)
+			done.add(1)
+		}
+	})
+
+	// Inputs left when ctx was done
+	for j := min(int(next.Load()), len(valid)); j < len(valid); j++ {
+		errs[valid[j]] = context.Cause(cfg.ctx)
+	}
+	done.report()
+
+	return outputs, errs
+}
@@ -0,0 +1,143 @@
+package pda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFindPDABatch_MatchesSequential(t *testing.T) {
+	programAddr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+
+	inputs := make([]ProgramDerivedAddressInput, 50)
+	for i := range inputs {
+		inputs[i] = ProgramDerivedAddressInput{
+			ProgramAddress: programAddr,
+			Seeds:          [][]byte{[]byte(fmt.Sprintf("seed-%d", i))},
+		}
+	}
+
+	outputs, errs := FindPDABatch(inputs, WithWorkers(4))
+	if len(outputs) != len(inputs) || len(errs) != len(inputs) {
+		t.Fatalf("expected %d results, got %d outputs and %d errors", len(inputs), len(outputs), len(errs))
+	}
+
+	for i, input := range inputs {
+		if errs[i] != nil {
+			t.Fatalf("input %d failed: %v", i, errs[i])
+		}
+
+		want, err := GetProgramDerivedAddress(input)
+		if err != nil {
+			t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+		}
+
+		if outputs[i] != want {
+			t.Errorf("input %d: got %+v, want %+v", i, outputs[i], want)
+		}
+	}
+}
+
+func TestFindPDABatch_PerItemErrors(t *testing.T) {
+	inputs := []ProgramDerivedAddressInput{
+		{ProgramAddress: Address{}, Seeds: [][]byte{[]byte("ok")}},
+		{ProgramAddress: Address{}, Seeds: [][]byte{make([]byte, MaxSeedLength+1)}},
+	}
+
+	outputs, errs := FindPDABatch(inputs)
+
+	if errs[0] != nil {
+		t.Errorf("expected first input to succeed, got: %v", errs[0])
+	}
+	if outputs[0].Address.IsZero() {
+		t.Error("expected non-empty address for first input")
+	}
+
+	var seedTooLongErr ErrSeedTooLong
+	if !errors.As(errs[1], &seedTooLongErr) {
+		t.Errorf("expected ErrSeedTooLong for second input, got: %v", errs[1])
+	}
+}
+
+func TestFindPDABatch_Empty(t *testing.T) {
+	outputs, errs := FindPDABatch(nil)
+	if len(outputs) != 0 || len(errs) != 0 {
+		t.Errorf("expected empty results, got %d outputs and %d errors", len(outputs), len(errs))
+	}
+}
+
+func TestFindPDABatch_ResolvesOptionsOnce(t *testing.T) {
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	inputs := make([]ProgramDerivedAddressInput, 20)
+	for i := range inputs {
+		inputs[i] = ProgramDerivedAddressInput{ProgramAddress: program, Seeds: [][]byte{{byte(i)}}}
+	}
+
+	calls := 0
+	counting := func(*config) { calls++ }
+
+	_, errs := FindPDABatch(inputs, WithWorkers(4), WithDeriveOptions(counting))
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("input %d: %v", i, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected options to be applied once, got %d", calls)
+	}
+}
+
+func TestFindPDABatch_Progress(t *testing.T) {
+	inputs := make([]ProgramDerivedAddressInput, 20)
+	for i := range inputs {
+		inputs[i].Seeds = [][]byte{[]byte(fmt.Sprintf("progress-%d", i))}
+	}
+	inputs[3].Seeds = [][]byte{make([]byte, MaxSeedLength+1)}
+
+	var reports []uint64
+	progress := func(done uint64, rate float64) {
+		reports = append(reports, done)
+	}
+	FindPDABatch(inputs, WithWorkers(2), WithBatchProgress(progress, time.Nanosecond))
+
+	if len(reports) < 2 {
+		t.Fatalf("expected periodic reports, got %v", reports)
+	}
+	if last := reports[len(reports)-1]; last != uint64(len(inputs)) {
+		t.Errorf("expected the final report to count all %d inputs, got %d", len(inputs), last)
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i] < reports[i-1] {
+			t.Errorf("progress went backwards: %v", reports)
+		}
+	}
+}
+
+func TestFindPDABatch_Context(t *testing.T) {
+	inputs := make([]ProgramDerivedAddressInput, 50)
+	for i := range inputs {
+		inputs[i].Seeds = [][]byte{[]byte(fmt.Sprintf("ctx-%d", i))}
+	}
+
+	// Cancel from the progress callback after the first input
+	ctx, cancel := context.WithCancelCause(context.Background())
+	stop := errors.New("stop")
+	progress := func(done uint64, rate float64) {
+		if done > 0 {
+			cancel(stop)
+		}
+	}
+	outputs, errs := FindPDABatch(inputs, WithWorkers(1), WithBatchContext(ctx), WithBatchProgress(progress, time.Nanosecond))
+
+	if errs[0] != nil || outputs[0].Address == (Address{}) {
+		t.Fatalf("expected the first input to be derived, got %v", errs[0])
+	}
+	if last := errs[len(errs)-1]; !errors.Is(last, stop) {
+		t.Errorf("expected the remaining inputs to fail with the cause, got %v", last)
+	}
+}
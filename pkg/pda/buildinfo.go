@@ -0,0 +1,72 @@
+package pda
+
+import "runtime/debug"
+
+// modulePath is the path of the module this package belongs to
+const modulePath = "raccoon-wasm"
+
+// version and commit override what the Go toolchain stamps into the binary,
+// for builds made outside a checkout:
+//
+//	go build -ldflags "-X raccoon-wasm/pkg/pda.commit=$SHA" ...
+var version, commit string
+
+// Build identifies the build of the library and the derivation parameters
+// in effect, so an address can be traced back to what derived it
+type Build struct {
+	// Version is the module version, "(devel)" for a build from a checkout
+	Version string `json:"version"`
+	// Commit is the VCS revision the binary was built from, if known
+	Commit string `json:"commit,omitempty"`
+	// Modified reports uncommitted changes in the build's checkout
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+
+	Marker        string `json:"marker"`
+	MaxSeeds      int    `json:"maxSeeds"`
+	MaxSeedLength int    `json:"maxSeedLength"`
+}
+
+// BuildInfo describes the running build and the marker and limits opts
+// select (the Solana defaults without any)
+func BuildInfo(opts ...Option) Build {
+	cfg := newConfig(opts)
+	b := Build{
+		Version:       "(devel)",
+		Marker:        string(cfg.marker),
+		MaxSeeds:      cfg.maxSeeds,
+		MaxSeedLength: cfg.maxSeedLength,
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		b.GoVersion = info.GoVersion
+		mod := &info.Main
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				mod = dep
+			}
+		}
+		if mod.Path == modulePath && mod.Version != "" {
+			b.Version = mod.Version
+		}
+		// The VCS settings describe the main module's checkout
+		if info.Main.Path == modulePath {
+			for _, s := range info.Settings {
+				switch s.Key {
+				case "vcs.revision":
+					b.Commit = s.Value
+				case "vcs.modified":
+					b.Modified = s.Value == "true"
+				}
+			}
+		}
+	}
+
+	if version != "" {
+		b.Version = version
+	}
+	if commit != "" {
+		b.Commit = commit
+	}
+	return b
+}
@@ -0,0 +1,27 @@
+package pda
+
+import "testing"
+
+func TestBuildInfo(t *testing.T) {
+	b := BuildInfo()
+	if b.Version == "" {
+		t.Error("empty version")
+	}
+	if b.Marker != "ProgramDerivedAddress" || b.MaxSeeds != MaxSeeds || b.MaxSeedLength != MaxSeedLength {
+		t.Errorf("unexpected defaults: %+v", b)
+	}
+
+	b = BuildInfo(WithMarker([]byte("Fork")), WithLimits(8, 64))
+	if b.Marker != "Fork" || b.MaxSeeds != 8 || b.MaxSeedLength != 64 {
+		t.Errorf("options not reflected: %+v", b)
+	}
+}
+
+func TestBuildInfo_Override(t *testing.T) {
+	defer func(v, c string) { version, commit = v, c }(version, commit)
+	version, commit = "v1.2.3", "abc123"
+
+	if b := BuildInfo(); b.Version != "v1.2.3" || b.Commit != "abc123" {
+		t.Errorf("got %s/%s, want v1.2.3/abc123", b.Version, b.Commit)
+	}
+}
@@ -0,0 +1,74 @@
+package pda
+
+import "iter"
+
+// EnumerateBumps validates the input and returns an iterator over every
+// off-curve (bump, address) pair, from 255 downward by default. Hashing is
+// lazy, so consumers that stop early skip the remaining candidates.
+func EnumerateBumps(input ProgramDerivedAddressInput, opts ...Option) (iter.Seq2[uint8, Address], error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(input.Seeds, 1, cfg); err != nil {
+		return nil, err
+	}
+
+	programIdBytes := [32]byte(input.ProgramAddress)
+
+	return func(yield func(uint8, Address) bool) {
+		bh := newBumpHasher(&programIdBytes, input.Seeds, cfg.marker)
+		defer bh.release()
+
+		for bump, digest := range bh.digests(cfg) {
+			if checkBump(bump, &digest, cfg) {
+				continue
+			}
+			if !yield(bump, Address(digest)) {
+				return
+			}
+		}
+	}, nil
+}
+
+// AllValidBumps returns every bump (255 down to 0 by default) that yields an
+// off-curve address for the given seeds and program. The first entry is the
+// canonical bump returned by GetProgramDerivedAddress.
+func AllValidBumps(input ProgramDerivedAddressInput, opts ...Option) ([]uint8, error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(input.Seeds, 1, cfg); err != nil {
+		return nil, err
+	}
+
+	programIdBytes := [32]byte(input.ProgramAddress)
+
+	// Only the bumps are needed, so skip building the (bump, address) pairs
+	var valid []uint8
+	bh := newBumpHasher(&programIdBytes, input.Seeds, cfg.marker)
+	defer bh.release()
+
+	for bump, digest := range bh.digests(cfg) {
+		if !checkBump(bump, &digest, cfg) {
+			valid = append(valid, bump)
+		}
+	}
+
+	return valid, nil
+}
+
+// FindBump returns only the canonical bump for the seeds and program,
+// without building the resulting address.
+func FindBump(programAddress Address, seeds [][]byte, opts ...Option) (uint8, error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(seeds, 1, cfg); err != nil {
+		return 0, err
+	}
+
+	programIdBytes := [32]byte(programAddress)
+
+	_, bump, err := findProgramAddress(&programIdBytes, seeds, cfg)
+	return bump, err
+}
@@ -0,0 +1,103 @@
+package pda
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+)
+
+// Cache stores derivation results for WithCache. Keys are opaque binary
+// strings identifying a program, seed list, marker and bump range; values
+// are the resulting address and bump. Implementations must be safe for
+// concurrent use, and Get may report a miss for any key (e.g. after
+// eviction or expiry).
+type Cache interface {
+	Get(key string) (ProgramDerivedAddressOutput, bool)
+	Put(key string, out ProgramDerivedAddressOutput)
+}
+
+var _ Cache = (*LRUCache)(nil)
+
+// LRUCache is the in-memory Cache implementation: a thread-safe,
+// fixed-size cache of derivation results. PDA derivation is pure, so a
+// workload that re-derives the same addresses can skip the bump search
+// entirely after the first call.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key string
+	out ProgramDerivedAddressOutput
+}
+
+// NewLRUCache creates a cache holding at most capacity results. Values
+// below 1 are treated as 1.
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached result for key and marks it as recently used
+func (c *LRUCache) Get(key string) (ProgramDerivedAddressOutput, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return ProgramDerivedAddressOutput{}, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*lruEntry).out, true
+}
+
+// Put stores out under key, evicting the least recently used result when
+// the cache is full
+func (c *LRUCache) Put(key string, out ProgramDerivedAddressOutput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).out = out
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry{key: key, out: out})
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of cached results
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// cacheKey identifies a bump search: the program, each seed (length-prefixed
+// so ["ab","c"] and ["a","bc"] differ), the marker and the bump range
+func cacheKey(programID *[32]byte, seeds [][]byte, cfg *config) string {
+	key := make([]byte, 0, 64)
+	key = append(key, programID[:]...)
+	key = binary.AppendUvarint(key, uint64(len(seeds)))
+	for _, seed := range seeds {
+		key = binary.AppendUvarint(key, uint64(len(seed)))
+		key = append(key, seed...)
+	}
+	key = append(key, cfg.bumpStart, cfg.bumpEnd)
+	key = append(key, cfg.marker...)
+	return string(key)
+}
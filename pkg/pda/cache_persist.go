@@ -0,0 +1,67 @@
+package pda
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// cacheRecord is one line of the cache export format:
+//
+//	{"key":"<base64 key>","address":"<base58>","bump":254}
+type cacheRecord struct {
+	Key     string  `json:"key"`
+	Address Address `json:"address"`
+	Bump    uint8   `json:"bump"`
+}
+
+// Export writes every cached result to w as JSON lines, least recently used
+// first, so that ImportCache into an LRUCache restores the same recency
+// order. The format is stable across releases.
+func (c *LRUCache) Export(w io.Writer) error {
+	c.mu.Lock()
+	records := make([]cacheRecord, 0, c.ll.Len())
+	for el := c.ll.Back(); el != nil; el = el.Prev() {
+		e := el.Value.(*lruEntry)
+		records = append(records, cacheRecord{
+			Key:     base64.StdEncoding.EncodeToString([]byte(e.key)),
+			Address: e.out.Address,
+			Bump:    e.out.Bump,
+		})
+	}
+	c.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ImportCache reads results written by LRUCache.Export into dst and
+// returns how many were imported. dst may be any Cache, so an export can
+// also seed a custom store.
+func ImportCache(r io.Reader, dst Cache) (int, error) {
+	dec := json.NewDecoder(r)
+	n := 0
+	for {
+		var rec cacheRecord
+		if err := dec.Decode(&rec); err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, fmt.Errorf("cache record %d: %w", n, err)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(rec.Key)
+		if err != nil {
+			return n, fmt.Errorf("cache record %d: %w", n, err)
+		}
+		dst.Put(string(key), ProgramDerivedAddressOutput{Address: rec.Address, Bump: rec.Bump})
+		n++
+	}
+}
@@ -0,0 +1,72 @@
+package pda
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLRUCache_ExportImport(t *testing.T) {
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	cache := NewLRUCache(16)
+
+	var want []ProgramDerivedAddressOutput
+	for _, seed := range []string{"a", "b", "c"} {
+		out, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+			ProgramAddress: program,
+			Seeds:          [][]byte{[]byte(seed)},
+		}, WithCache(cache))
+		if err != nil {
+			t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+		}
+		want = append(want, out)
+	}
+
+	var buf bytes.Buffer
+	if err := cache.Export(&buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", lines, buf.String())
+	}
+
+	restored := NewLRUCache(2)
+	n, err := ImportCache(&buf, restored)
+	if err != nil {
+		t.Fatalf("ImportCache failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 imported records, got %d", n)
+	}
+
+	// Recency survives the round trip: "a" was the oldest and got evicted
+	attempts := 0
+	hook := WithTraceHook(func(uint8, [32]byte, bool) { attempts++ })
+	for i, seed := range []string{"b", "c"} {
+		got, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+			ProgramAddress: program,
+			Seeds:          [][]byte{[]byte(seed)},
+		}, WithCache(restored), hook)
+		if err != nil {
+			t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+		}
+		if got != want[i+1] {
+			t.Errorf("seed %q: got %+v, want %+v", seed, got, want[i+1])
+		}
+	}
+	if attempts != 0 {
+		t.Errorf("expected imported entries to be cache hits, got %d attempts", attempts)
+	}
+}
+
+func TestImportCache_Invalid(t *testing.T) {
+	for _, in := range []string{
+		`{"key":"!!","address":"11111111111111111111111111111111","bump":1}`,
+		`{"key":"YQ==","address":"not-base58","bump":1}`,
+		`not json`,
+	} {
+		if _, err := ImportCache(strings.NewReader(in), NewLRUCache(1)); err == nil {
+			t.Errorf("expected an error for %s", in)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package pda
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestLRUCache_Eviction(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Put("a", ProgramDerivedAddressOutput{Bump: 1})
+	c.Put("b", ProgramDerivedAddressOutput{Bump: 2})
+
+	// Touch "a" so "b" becomes the least recently used
+	if out, ok := c.Get("a"); !ok || out.Bump != 1 {
+		t.Fatalf("expected a hit for a, got %+v, %v", out, ok)
+	}
+	c.Put("c", ProgramDerivedAddressOutput{Bump: 3})
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected a to survive eviction")
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+}
+
+func TestWithCache(t *testing.T) {
+	cache := NewLRUCache(16)
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("cached")},
+	}
+
+	want, err := GetProgramDerivedAddress(input)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	attempts := 0
+	hook := WithTraceHook(func(uint8, [32]byte, bool) { attempts++ })
+
+	for i := 0; i < 3; i++ {
+		got, err := GetProgramDerivedAddress(input, WithCache(cache), hook)
+		if err != nil {
+			t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("call %d: got %+v, want %+v", i, got, want)
+		}
+	}
+
+	if attempts != 256-int(want.Bump) {
+		t.Errorf("expected only the first call to search, got %d attempts", attempts)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 cached entry, got %d", cache.Len())
+	}
+
+	// A different marker must not reuse the entry
+	if _, err := GetProgramDerivedAddress(input, WithCache(cache), WithMarker([]byte("Fork"))); err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected a separate entry per marker, got %d", cache.Len())
+	}
+}
+
+func TestCacheKey_SeedBoundaries(t *testing.T) {
+	program := [32]byte{}
+	cfg := newConfig(nil)
+
+	a := cacheKey(&program, [][]byte{[]byte("ab"), []byte("c")}, cfg)
+	b := cacheKey(&program, [][]byte{[]byte("a"), []byte("bc")}, cfg)
+	if a == b {
+		t.Error("expected seed boundaries to be part of the key")
+	}
+}
+
+func TestLRUCache_Concurrent(t *testing.T) {
+	cache := NewLRUCache(8)
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				input := ProgramDerivedAddressInput{
+					ProgramAddress: program,
+					Seeds:          [][]byte{[]byte(fmt.Sprintf("seed-%d", i%12))},
+				}
+				if _, err := GetProgramDerivedAddress(input, WithCache(cache)); err != nil {
+					t.Errorf("GetProgramDerivedAddress failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if cache.Len() > 8 {
+		t.Errorf("cache grew past its capacity: %d", cache.Len())
+	}
+}
+
+// mapCache is a minimal custom Cache implementation
+type mapCache struct {
+	mu   sync.Mutex
+	m    map[string]ProgramDerivedAddressOutput
+	gets int
+}
+
+func (c *mapCache) Get(key string) (ProgramDerivedAddressOutput, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gets++
+	out, ok := c.m[key]
+	return out, ok
+}
+
+func (c *mapCache) Put(key string, out ProgramDerivedAddressOutput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = out
+}
+
+func TestWithCache_CustomImplementation(t *testing.T) {
+	cache := &mapCache{m: map[string]ProgramDerivedAddressOutput{}}
+	d := NewDeriver(MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"), WithCache(cache))
+
+	first, err := d.Find([]byte("custom"))
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	second, err := d.Find([]byte("custom"))
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("cached result differs: %+v vs %+v", first, second)
+	}
+	if len(cache.m) != 1 || cache.gets != 2 {
+		t.Errorf("expected 1 entry and 2 lookups, got %d and %d", len(cache.m), cache.gets)
+	}
+}
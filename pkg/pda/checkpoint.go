@@ -0,0 +1,191 @@
+package pda
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// GrindCheckpoint is the resumable state of a deterministic grind, saved as
+// JSON. Candidate n is derived from sha256(Base || n as u64 little-endian),
+// so Base is as sensitive as a private key: checkpoint files are written
+// with mode 0600.
+type GrindCheckpoint struct {
+	// Kind and the pattern fields identify the job; resuming a checkpoint
+	// for a different job is an error
+	Kind   string `json:"kind"`
+	Prefix string `json:"prefix"`
+	Suffix string `json:"suffix"`
+	Regexp string `json:"regexp,omitempty"`
+	// Scope fingerprints the rest of the job, e.g. a PDA grind's program
+	// and seeds
+	Scope string `json:"scope,omitempty"`
+
+	Base [32]byte `json:"base"`
+	// Next is the lowest candidate not known to have been tried; every
+	// candidate below it has been
+	Next uint64 `json:"next"`
+	// Attempts counts candidates tried across all runs. Candidates retried
+	// after a resume are counted again.
+	Attempts uint64 `json:"attempts"`
+}
+
+// WithCheckpoint saves the grind's state to path every interval, and once
+// more when the grind stops, so an interrupted job can continue with
+// WithResume instead of starting over. It switches the grind from random
+// candidates to a deterministic sequence; the odds of a match per attempt
+// are unchanged.
+func WithCheckpoint(path string, interval time.Duration) GrindOption {
+	return func(c *grindConfig) {
+		c.checkpoint = path
+		c.checkpointInterval = interval
+	}
+}
+
+// WithResume continues the grind saved at path by WithCheckpoint. The
+// checkpoint must be for the same kind of grind and the same pattern.
+// Candidates that were in flight when it was saved are tried again.
+func WithResume(path string) GrindOption {
+	return func(c *grindConfig) {
+		c.resume = path
+	}
+}
+
+// LoadGrindCheckpoint reads a checkpoint written by WithCheckpoint
+func LoadGrindCheckpoint(path string) (GrindCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return GrindCheckpoint{}, err
+	}
+	var cp GrindCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return GrindCheckpoint{}, fmt.Errorf("checkpoint %s: %w", path, err)
+	}
+	return cp, nil
+}
+
+// save writes cp atomically, so a crash mid-write leaves the previous
+// checkpoint intact
+func (cp GrindCheckpoint) save(path string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// counterSeed derives candidate n of a deterministic grind
+func counterSeed(base *[32]byte, n uint64) [32]byte {
+	var buf [40]byte
+	copy(buf[:], base[:])
+	binary.LittleEndian.PutUint64(buf[32:], n)
+	return sha256.Sum256(buf[:])
+}
+
+// counterSearch runs a deterministic, checkpointed grind: workers take
+// candidate numbers from a shared counter and try(base, n) tests each one.
+// job identifies the grind; its Base, Next and Attempts are filled in from
+// the resumed checkpoint or freshly.
+func counterSearch[T any](ctx context.Context, workers int, cfg *grindConfig, job GrindCheckpoint, try func(worker int, base *[32]byte, n uint64) (T, bool)) (T, error) {
+	var zero T
+
+	if cfg.resume != "" {
+		cp, err := LoadGrindCheckpoint(cfg.resume)
+		if err != nil {
+			return zero, err
+		}
+		if cp.Kind != job.Kind || cp.Prefix != job.Prefix || cp.Suffix != job.Suffix || cp.Regexp != job.Regexp || cp.Scope != job.Scope {
+			return zero, fmt.Errorf("checkpoint %s is for a different grind (%s, prefix %q, suffix %q)", cfg.resume, cp.Kind, cp.Prefix, cp.Suffix)
+		}
+		job.Base, job.Next, job.Attempts = cp.Base, cp.Next, cp.Attempts
+	} else {
+		rand.Read(job.Base[:])
+	}
+
+	var counter atomic.Uint64
+	counter.Store(job.Next)
+
+	// inflight[w] holds worker w's current candidate plus one (0 = none),
+	// so the low-watermark of finished candidates can be computed
+	inflight := make([]atomic.Uint64, workers)
+	snapshot := func() GrindCheckpoint {
+		cp := job
+		cp.Next = counter.Load()
+		for i := range inflight {
+			if v := inflight[i].Load(); v != 0 && v-1 < cp.Next {
+				cp.Next = v - 1
+			}
+		}
+		cp.Attempts = job.Attempts + (counter.Load() - job.Next)
+		return cp
+	}
+
+	var saveErr error
+	var saver sync.WaitGroup
+	stop := make(chan struct{})
+	if cfg.checkpoint != "" && cfg.checkpointInterval > 0 {
+		ticker := time.NewTicker(cfg.checkpointInterval)
+		defer ticker.Stop()
+		saver.Add(1)
+		go func() {
+			defer saver.Done()
+			for {
+				select {
+				case <-ticker.C:
+					if err := snapshot().save(cfg.checkpoint); err != nil {
+						saveErr = err
+					}
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+
+	res, err := parallelSearch(ctx, workers, cfg, func(w int) (T, bool) {
+		n := counter.Add(1) - 1
+		inflight[w].Store(n + 1)
+		return try(w, &job.Base, n)
+	})
+
+	close(stop)
+	saver.Wait()
+	if cfg.checkpoint != "" {
+		if err := snapshot().save(cfg.checkpoint); err != nil {
+			saveErr = err
+		}
+	}
+
+	// A failed save only matters for an interrupted job; a match is still
+	// returned
+	if err != nil && saveErr != nil {
+		return zero, fmt.Errorf("%w (and saving the checkpoint failed: %v)", err, saveErr)
+	}
+	return res, err
+}
@@ -0,0 +1,61 @@
+package pda
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrorCode is a stable, machine-readable error identifier. The same
+// strings are returned by the WASM bridge, so JavaScript callers can branch
+// on codes instead of matching error messages.
+type ErrorCode string
+
+const (
+	CodeMaxSeeds        ErrorCode = "PDA_ERR_MAX_SEEDS"
+	CodeSeedTooLong     ErrorCode = "PDA_ERR_SEED_TOO_LONG"
+	CodeMaxSeedLength   ErrorCode = "PDA_ERR_MAX_SEED_LENGTH_EXCEEDED"
+	CodeOnCurve         ErrorCode = "PDA_ERR_ON_CURVE"
+	CodeBadBase58       ErrorCode = "PDA_ERR_BAD_BASE58"
+	CodeBadLength       ErrorCode = "PDA_ERR_BAD_LENGTH"
+	CodeNoViableBump    ErrorCode = "PDA_ERR_NO_VIABLE_BUMP"
+	CodeBadSeedSpec     ErrorCode = "PDA_ERR_BAD_SEED_SPEC"
+	CodeIllegalOwner    ErrorCode = "PDA_ERR_ILLEGAL_OWNER"
+	CodeNonCanonical    ErrorCode = "PDA_ERR_NON_CANONICAL_BUMP"
+	CodeCanceled        ErrorCode = "PDA_ERR_CANCELED"
+	CodeInvalidArgument ErrorCode = "PDA_ERR_INVALID_ARGUMENT"
+	CodeUnknown         ErrorCode = "PDA_ERR_UNKNOWN"
+)
+
+// coder is implemented by the package's error types
+type coder interface {
+	Code() ErrorCode
+}
+
+// ErrorCodeOf returns the ErrorCode for err, looking through wrapped
+// errors. It returns "" for a nil error and CodeUnknown for errors that do
+// not originate from this package.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	var c coder
+	if errors.As(err, &c) {
+		return c.Code()
+	}
+
+	switch {
+	case errors.Is(err, ErrPointOnCurve):
+		return CodeOnCurve
+	case errors.Is(err, ErrInvalidBase58):
+		return CodeBadBase58
+	case errors.Is(err, ErrMaxSeedLengthExceeded):
+		return CodeMaxSeedLength
+	case errors.Is(err, ErrIllegalOwner):
+		return CodeIllegalOwner
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return CodeCanceled
+	}
+
+	return CodeUnknown
+}
@@ -0,0 +1,64 @@
+package pda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	_, badLength := NewAddress("1111")
+	_, badSpec := ParseSeedSpec("u8:300")
+
+	tests := []struct {
+		err  error
+		want ErrorCode
+	}{
+		{nil, ""},
+		{ErrMaxSeedsExceeded{Count: 17, Max: 16}, CodeMaxSeeds},
+		{ErrSeedTooLong{Index: 0, Length: 33, Max: 32}, CodeSeedTooLong},
+		{ErrPointOnCurve, CodeOnCurve},
+		{ErrInvalidBase58, CodeBadBase58},
+		{ErrNoViableBump{}, CodeNoViableBump},
+		{ErrIllegalOwner, CodeIllegalOwner},
+		{fmt.Errorf("grind: %w", context.Canceled), CodeCanceled},
+		{badLength, CodeBadLength},
+		{badSpec, CodeBadSeedSpec},
+		{ErrInvalidPattern{Reason: "bad"}, CodeInvalidArgument},
+		{fmt.Errorf("wrapped: %w", ErrSeedTooLong{}), CodeSeedTooLong},
+		{errors.New("something else"), CodeUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorCodeOf(tt.err); got != tt.want {
+			t.Errorf("ErrorCodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestFindPDA_TooManySeedsCode(t *testing.T) {
+	_, _, err := FindPDA(ZeroAddress.String(), make([][]byte, MaxSeeds+1))
+	if ErrorCodeOf(err) != CodeMaxSeeds {
+		t.Errorf("expected %s, got: %v", CodeMaxSeeds, err)
+	}
+}
+
+func TestFindPDA_SeedTooLongCode(t *testing.T) {
+	seeds := [][]byte{[]byte("ok"), make([]byte, MaxSeedLength+1)}
+	_, _, err := FindPDA(ZeroAddress.String(), seeds)
+	if ErrorCodeOf(err) != CodeSeedTooLong {
+		t.Fatalf("expected %s, got: %v", CodeSeedTooLong, err)
+	}
+	var tooLong ErrSeedTooLong
+	if !errors.As(err, &tooLong) || tooLong.Index != 1 {
+		t.Errorf("expected seed index 1, got: %v", err)
+	}
+}
+
+func TestFindPDA_NoRoomForBump(t *testing.T) {
+	_, _, err := FindPDA(ZeroAddress.String(), make([][]byte, MaxSeeds))
+	if ErrorCodeOf(err) != CodeMaxSeeds {
+		t.Errorf("expected %s, got: %v", CodeMaxSeeds, err)
+	}
+}
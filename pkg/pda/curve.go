@@ -0,0 +1,41 @@
+package pda
+
+import "filippo.io/edwards25519/field"
+
+// curveD is the edwards25519 curve constant d = -121665/121666 mod p
+var curveD, _ = new(field.Element).SetBytes([]byte{
+	0xa3, 0x78, 0x59, 0x13, 0xca, 0x4d, 0xeb, 0x75,
+	0xab, 0xd8, 0x41, 0x41, 0x4d, 0x0a, 0x70, 0x00,
+	0x98, 0xe8, 0x79, 0x77, 0x79, 0x40, 0xc7, 0x8c,
+	0x73, 0xfe, 0x6f, 0x2b, 0xee, 0x6c, 0x03, 0x52,
+})
+
+// isValidPoint reports whether b is a valid compressed edwards25519 point,
+// using the same acceptance rules as edwards25519.Point.SetBytes. Unlike
+// SetBytes it never allocates: it only checks that x^2 = (y^2-1)/(dy^2+1)
+// has a square root, without building the point.
+func isValidPoint(b *[32]byte) bool {
+	var y, y2, u, v, x, one field.Element
+	if _, err := y.SetBytes(b[:]); err != nil {
+		return false
+	}
+	one.One()
+	y2.Square(&y)
+	u.Subtract(&y2, &one)
+	v.Multiply(&y2, curveD)
+	v.Add(&v, &one)
+	_, wasSquare := x.SqrtRatio(&u, &v)
+	return wasSquare == 1
+}
+
+// AreOnCurve classifies addrs in bulk, reporting for each whether it is a
+// valid ed25519 point (keypair-like) rather than off-curve (PDA-like). It
+// uses the allocation-free point check, so the only allocation is the
+// result slice. The error is always nil, as with Address.IsOnCurve.
+func AreOnCurve(addrs []Address) ([]bool, error) {
+	out := make([]bool, len(addrs))
+	for i := range addrs {
+		out[i] = isValidPoint((*[32]byte)(&addrs[i]))
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package pda
+
+import "testing"
+
+func TestAreOnCurve(t *testing.T) {
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	pda, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: program,
+		Seeds:          [][]byte{[]byte("curve")},
+	})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	addrs := []Address{program, pda.Address, ZeroAddress}
+	got, err := AreOnCurve(addrs)
+	if err != nil {
+		t.Fatalf("AreOnCurve failed: %v", err)
+	}
+
+	for i, addr := range addrs {
+		want, _ := addr.IsOnCurve()
+		if got[i] != want {
+			t.Errorf("%s: AreOnCurve = %v, IsOnCurve = %v", addr, got[i], want)
+		}
+	}
+	if !got[0] || got[1] {
+		t.Errorf("expected [true false ...], got %v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package pda
+
+// Deriver derives PDAs for a single program, applying the same options to
+// every derivation.
+type Deriver struct {
+	program Address
+	cfg     *config
+}
+
+// NewDeriver creates a Deriver for the given program address. The options
+// apply to every derivation made through it.
+func NewDeriver(programAddress Address, opts ...Option) *Deriver {
+	return &Deriver{program: programAddress, cfg: newConfig(opts)}
+}
+
+// Program returns the program address this Deriver derives for
+func (d *Deriver) Program() Address {
+	return d.program
+}
+
+// Find searches for a valid PDA and its canonical bump seed
+func (d *Deriver) Find(seeds ...[]byte) (ProgramDerivedAddressOutput, error) {
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(seeds, 1, d.cfg); err != nil {
+		return ProgramDerivedAddressOutput{}, err
+	}
+
+	digest, bump, err := findProgramAddress((*[32]byte)(&d.program), seeds, d.cfg)
+	if err != nil {
+		return ProgramDerivedAddressOutput{}, err
+	}
+
+	return ProgramDerivedAddressOutput{
+		Address: Address(digest),
+		Bump:    bump,
+	}, nil
+}
+
+// Create derives a PDA from the seeds as-is (the bump must already be included)
+func (d *Deriver) Create(seeds ...[]byte) (Address, error) {
+	if err := validateSeeds(seeds, 0, d.cfg); err != nil {
+		return Address{}, err
+	}
+
+	digest, err := createProgramAddress((*[32]byte)(&d.program), seeds, d.cfg)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return Address(digest), nil
+}
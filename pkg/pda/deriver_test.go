@@ -0,0 +1,54 @@
+package pda
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeriver_MatchesGetProgramDerivedAddress(t *testing.T) {
+	programAddr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+
+	d := NewDeriver(programAddr)
+
+	seeds := [][]byte{[]byte("vault"), []byte{1, 2, 3}}
+
+	got, err := d.Find(seeds...)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	want, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: programAddr,
+		Seeds:          seeds,
+	})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("Deriver.Find = %+v, want %+v", got, want)
+	}
+
+	created, err := d.Create(append(seeds, []byte{got.Bump})...)
+	if err != nil {
+		t.Fatalf("Create failed with returned bump: %v", err)
+	}
+
+	if created != got.Address {
+		t.Errorf("addresses don't match: %s != %s", created, got.Address)
+	}
+}
+
+func TestDeriver_SeedTooLong(t *testing.T) {
+	d := NewDeriver(Address{})
+
+	_, err := d.Find(make([]byte, MaxSeedLength+1))
+
+	var seedTooLongErr ErrSeedTooLong
+	if !errors.As(err, &seedTooLongErr) {
+		t.Errorf("expected ErrSeedTooLong, got: %v", err)
+	}
+}
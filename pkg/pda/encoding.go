@@ -0,0 +1,120 @@
+package pda
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MarshalJSON encodes the Address as a base58 JSON string
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes a base58 JSON string, rejecting anything that is not
+// a valid 32-byte address. A JSON null leaves the Address unchanged.
+func (a *Address) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("address must be a base58 string: %w", err)
+	}
+
+	addr, err := NewAddress(s)
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// MarshalText encodes the Address as base58 text
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes base58 text, validating it
+func (a *Address) UnmarshalText(text []byte) error {
+	addr, err := NewAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// MarshalBinary returns the 32 raw bytes of the Address
+func (a Address) MarshalBinary() ([]byte, error) {
+	return a[:], nil
+}
+
+// UnmarshalBinary decodes exactly 32 raw bytes
+func (a *Address) UnmarshalBinary(data []byte) error {
+	addr, err := NewAddressFromBytes(data)
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// Value stores the Address as its base58 string
+func (a Address) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan reads an Address from a base58 text column or a 32-byte binary
+// (bytea/BLOB) column. Use sql.Null[Address] for nullable columns.
+func (a *Address) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		// Text columns often arrive as []byte; prefer base58 and fall back
+		// to raw bytes for binary columns
+		if addr, err := NewAddress(string(v)); err == nil {
+			*a = addr
+			return nil
+		}
+		return a.UnmarshalBinary(v)
+	case nil:
+		return errors.New("cannot scan NULL into Address")
+	}
+	return fmt.Errorf("cannot scan %T into Address", src)
+}
+
+// AddressFromHex decodes a 64-character hex string (an optional 0x prefix
+// is accepted)
+func AddressFromHex(s string) (Address, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid hex address: %w", err)
+	}
+	return NewAddressFromBytes(b)
+}
+
+// AddressFromBase64 decodes a standard (padded) base64 string
+func AddressFromBase64(s string) (Address, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid base64 address: %w", err)
+	}
+	return NewAddressFromBytes(b)
+}
+
+// ToHex returns the lowercase hex encoding of the Address, without prefix
+func (a Address) ToHex() string {
+	return hex.EncodeToString(a[:])
+}
+
+// ToBase64 returns the standard base64 encoding of the Address
+func (a Address) ToBase64() string {
+	return base64.StdEncoding.EncodeToString(a[:])
+}
@@ -0,0 +1,194 @@
+package pda
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAddress_JSON(t *testing.T) {
+	addr := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	data, err := json.Marshal(addr)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var decoded Address
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded != addr {
+		t.Errorf("round trip mismatch: %s != %s", decoded, addr)
+	}
+}
+
+func TestAddress_JSONRejectsInvalid(t *testing.T) {
+	var addr Address
+
+	if err := json.Unmarshal([]byte(`"invalid-base58-!@#$"`), &addr); !errors.Is(err, ErrInvalidBase58) {
+		t.Errorf("expected ErrInvalidBase58, got: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`"1111"`), &addr); err == nil {
+		t.Error("expected error for short address")
+	}
+	if err := json.Unmarshal([]byte(`42`), &addr); err == nil {
+		t.Error("expected error for non-string address")
+	}
+}
+
+func TestProgramDerivedAddressOutput_JSON(t *testing.T) {
+	out := ProgramDerivedAddressOutput{
+		Address: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Bump:    254,
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"address":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","bump":254}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var decoded ProgramDerivedAddressOutput
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded != out {
+		t.Errorf("round trip mismatch: %+v != %+v", decoded, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"address":"not-base58!","bump":1}`), &decoded); err == nil {
+		t.Error("expected error for invalid address in output")
+	}
+}
+
+func TestAddress_TextAndBinary(t *testing.T) {
+	addr := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	text, err := addr.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText failed: %v", err)
+	}
+	var fromText Address
+	if err := fromText.UnmarshalText(text); err != nil || fromText != addr {
+		t.Errorf("text round trip failed: %s, %v", fromText, err)
+	}
+
+	bin, err := addr.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	var fromBin Address
+	if err := fromBin.UnmarshalBinary(bin); err != nil || fromBin != addr {
+		t.Errorf("binary round trip failed: %s, %v", fromBin, err)
+	}
+	if err := fromBin.UnmarshalBinary(bin[:31]); err == nil {
+		t.Error("expected error for 31-byte input")
+	}
+
+	// Text marshaling makes Address usable as a JSON map key
+	data, err := json.Marshal(map[Address]int{addr: 1})
+	if err != nil {
+		t.Fatalf("Marshal map failed: %v", err)
+	}
+	if string(data) != `{"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA":1}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestAddress_Gob(t *testing.T) {
+	addr := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(addr); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	var decoded Address
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded != addr {
+		t.Errorf("gob round trip mismatch: %s != %s", decoded, addr)
+	}
+}
+
+func TestAddress_SQL(t *testing.T) {
+	addr := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	v, err := addr.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if v != "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA" {
+		t.Errorf("unexpected driver value: %v", v)
+	}
+
+	sources := []any{
+		"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+		[]byte("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		addr[:],
+	}
+	for _, src := range sources {
+		var scanned Address
+		if err := scanned.Scan(src); err != nil {
+			t.Errorf("Scan(%T) failed: %v", src, err)
+			continue
+		}
+		if scanned != addr {
+			t.Errorf("Scan(%T) = %s, want %s", src, scanned, addr)
+		}
+	}
+
+	// The zero key is 32 base58 characters, so it must not be read as raw bytes
+	var zero Address
+	if err := zero.Scan([]byte("11111111111111111111111111111111")); err != nil || !zero.IsZero() {
+		t.Errorf("Scan of zero key failed: %s, %v", zero, err)
+	}
+
+	var invalid Address
+	if err := invalid.Scan(nil); err == nil {
+		t.Error("expected error scanning NULL")
+	}
+	if err := invalid.Scan(42); err == nil {
+		t.Error("expected error scanning int")
+	}
+}
+
+func TestAddress_HexAndBase64(t *testing.T) {
+	addr := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	const wantHex = "06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9"
+	if got := addr.ToHex(); got != wantHex {
+		t.Errorf("ToHex() = %s", got)
+	}
+
+	for _, s := range []string{wantHex, "0x" + wantHex} {
+		fromHex, err := AddressFromHex(s)
+		if err != nil || fromHex != addr {
+			t.Errorf("AddressFromHex(%q) = %s, %v", s, fromHex, err)
+		}
+	}
+
+	fromBase64, err := AddressFromBase64(addr.ToBase64())
+	if err != nil || fromBase64 != addr {
+		t.Errorf("base64 round trip failed: %s, %v", fromBase64, err)
+	}
+
+	if _, err := AddressFromHex("zz"); err == nil {
+		t.Error("expected error for invalid hex")
+	}
+	if _, err := AddressFromHex("deadbeef"); err == nil {
+		t.Error("expected error for short hex")
+	}
+	if _, err := AddressFromBase64("AQI="); err == nil {
+		t.Error("expected error for short base64")
+	}
+}
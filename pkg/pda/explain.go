@@ -0,0 +1,65 @@
+package pda
+
+import "encoding/hex"
+
+// PreimagePart is one contiguous field of the bytes hashed to form a PDA
+type PreimagePart struct {
+	// Kind is "seed", "bump", "program" or "marker"
+	Kind string `json:"kind"`
+	// Index is the seed's position for Kind "seed" and 0 otherwise
+	Index int `json:"index"`
+	// Offset is the field's byte offset within the preimage
+	Offset int `json:"offset"`
+	// Hex is the field's bytes, hex-encoded
+	Hex string `json:"hex"`
+}
+
+// Explanation breaks down the winning derivation byte by byte
+type Explanation struct {
+	Address Address        `json:"address"`
+	Bump    uint8          `json:"bump"`
+	Parts   []PreimagePart `json:"parts"`
+	// Preimage is the full hash input, hex-encoded:
+	// seeds || bump || program ID || marker
+	Preimage string          `json:"preimage"`
+	Stats    DerivationStats `json:"stats"`
+}
+
+// ExplainPDA derives the PDA like GetProgramDerivedAddress and returns the
+// exact byte layout hashed for the winning bump. Comparing Preimage with the
+// bytes an on-chain program hashes pinpoints seed encoding mismatches.
+func ExplainPDA(input ProgramDerivedAddressInput, opts ...Option) (Explanation, error) {
+	out, stats, err := FindPDAWithStats(input, opts...)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	cfg := newConfig(opts)
+
+	var preimage []byte
+	parts := make([]PreimagePart, 0, len(input.Seeds)+3)
+	add := func(kind string, index int, b []byte) {
+		parts = append(parts, PreimagePart{
+			Kind:   kind,
+			Index:  index,
+			Offset: len(preimage),
+			Hex:    hex.EncodeToString(b),
+		})
+		preimage = append(preimage, b...)
+	}
+
+	for i, seed := range input.Seeds {
+		add("seed", i, seed)
+	}
+	add("bump", 0, []byte{out.Bump})
+	add("program", 0, input.ProgramAddress[:])
+	add("marker", 0, cfg.marker)
+
+	return Explanation{
+		Address:  out.Address,
+		Bump:     out.Bump,
+		Parts:    parts,
+		Preimage: hex.EncodeToString(preimage),
+		Stats:    stats,
+	}, nil
+}
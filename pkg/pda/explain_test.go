@@ -0,0 +1,62 @@
+package pda
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestExplainPDA(t *testing.T) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("vault"), {1, 2, 3}},
+	}
+
+	want, err := GetProgramDerivedAddress(input)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	exp, err := ExplainPDA(input)
+	if err != nil {
+		t.Fatalf("ExplainPDA failed: %v", err)
+	}
+
+	if exp.Address != want.Address || exp.Bump != want.Bump {
+		t.Errorf("got %s/%d, want %s/%d", exp.Address, exp.Bump, want.Address, want.Bump)
+	}
+
+	// Hashing the reported preimage reproduces the address
+	preimage, err := hex.DecodeString(exp.Preimage)
+	if err != nil {
+		t.Fatalf("invalid preimage hex: %v", err)
+	}
+	if Address(sha256.Sum256(preimage)) != exp.Address {
+		t.Error("sha256(preimage) does not match the address")
+	}
+
+	wantParts := []PreimagePart{
+		{Kind: "seed", Index: 0, Offset: 0, Hex: "7661756c74"},
+		{Kind: "seed", Index: 1, Offset: 5, Hex: "010203"},
+		{Kind: "bump", Offset: 8, Hex: hex.EncodeToString([]byte{want.Bump})},
+		{Kind: "program", Offset: 9, Hex: "06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9"},
+		{Kind: "marker", Offset: 41, Hex: hex.EncodeToString([]byte("ProgramDerivedAddress"))},
+	}
+	if len(exp.Parts) != len(wantParts) {
+		t.Fatalf("expected %d parts, got %d", len(wantParts), len(exp.Parts))
+	}
+	for i := range wantParts {
+		if exp.Parts[i] != wantParts[i] {
+			t.Errorf("part %d: got %+v, want %+v", i, exp.Parts[i], wantParts[i])
+		}
+	}
+}
+
+func TestExplainPDA_InvalidSeeds(t *testing.T) {
+	_, err := ExplainPDA(ProgramDerivedAddressInput{
+		Seeds: [][]byte{make([]byte, MaxSeedLength+1)},
+	})
+	if err == nil {
+		t.Error("expected an error for an oversized seed")
+	}
+}
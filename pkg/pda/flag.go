@@ -0,0 +1,50 @@
+package pda
+
+import "strings"
+
+// Set parses a base58 address, implementing flag.Value (and pflag.Value)
+func (a *Address) Set(s string) error {
+	return a.UnmarshalText([]byte(s))
+}
+
+// Type names the flag value type for pflag
+func (a Address) Type() string {
+	return "address"
+}
+
+// SeedsFlag collects seeds from a repeatable command-line flag. Each value
+// is a ParseSeedSpec string, so both "-seed str:vault -seed u64:42" and
+// "-seed str:vault,u64:42" work. It implements flag.Value and pflag.Value.
+type SeedsFlag struct {
+	specs []string
+	seeds [][]byte
+}
+
+// Set parses and appends one seed spec
+func (f *SeedsFlag) Set(spec string) error {
+	seeds, err := ParseSeedSpec(spec)
+	if err != nil {
+		return err
+	}
+	f.specs = append(f.specs, spec)
+	f.seeds = append(f.seeds, seeds...)
+	return nil
+}
+
+// String returns the specs given so far, joined by commas
+func (f *SeedsFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(f.specs, ",")
+}
+
+// Type names the flag value type for pflag
+func (f *SeedsFlag) Type() string {
+	return "seeds"
+}
+
+// Seeds returns the parsed seeds in flag order
+func (f *SeedsFlag) Seeds() [][]byte {
+	return f.seeds
+}
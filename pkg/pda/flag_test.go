@@ -0,0 +1,63 @@
+package pda
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestFlagValues(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var program Address
+	var seeds SeedsFlag
+	fs.Var(&program, "program", "program ID")
+	fs.Var(&seeds, "seed", "typed seed spec (repeatable)")
+
+	err := fs.Parse([]string{
+		"-program", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+		"-seed", "str:vault",
+		"-seed", "u64:42,u8:1",
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if program != MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA") {
+		t.Errorf("unexpected program: %s", program)
+	}
+
+	want := [][]byte{[]byte("vault"), SeedU64LE(42), {1}}
+	got := seeds.Seeds()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d seeds, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !bytes.Equal(got[i], want[i]) {
+			t.Errorf("seed %d: got %x, want %x", i, got[i], want[i])
+		}
+	}
+
+	if seeds.String() != "str:vault,u64:42,u8:1" {
+		t.Errorf("unexpected String(): %q", seeds.String())
+	}
+}
+
+func TestFlagValues_Invalid(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var program Address
+	var seeds SeedsFlag
+	fs.Var(&program, "program", "program ID")
+	fs.Var(&seeds, "seed", "typed seed spec (repeatable)")
+
+	if err := fs.Parse([]string{"-program", "not-base58!"}); err == nil {
+		t.Error("expected error for invalid program")
+	}
+	if err := fs.Parse([]string{"-seed", "u8:300"}); err == nil {
+		t.Error("expected error for invalid seed")
+	}
+}
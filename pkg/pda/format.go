@@ -0,0 +1,52 @@
+package pda
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// shortHalf is the number of base58 characters kept on each side by Short
+const shortHalf = 4
+
+// Short returns an abbreviated form such as "Toke…Q5DA" for log lines
+func (a Address) Short() string {
+	return abbreviate(a.String(), 2*shortHalf)
+}
+
+// abbreviate keeps n characters of s, split between head and tail
+func abbreviate(s string, n int) string {
+	if n <= 0 || n >= len(s) {
+		return s
+	}
+	head := n / 2
+	return s[:head] + "…" + s[len(s)-(n-head):]
+}
+
+// Format implements fmt.Formatter:
+//
+//	%s    full base58
+//	%q    quoted base58
+//	%v    full base58; with a width (%8v) the short form keeping that many
+//	      characters, e.g. "Toke…Q5DA"
+//
+// Other verbs format the raw bytes, so %x prints hex.
+func (a Address) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), a.String())
+	case 'q':
+		fmt.Fprint(f, strconv.Quote(a.String()))
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprintf(f, "pda.MustNewAddress(%q)", a.String())
+			return
+		}
+		if width, ok := f.Width(); ok {
+			fmt.Fprint(f, abbreviate(a.String(), width))
+			return
+		}
+		fmt.Fprint(f, a.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), [32]byte(a))
+	}
+}
@@ -0,0 +1,40 @@
+package pda
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAddress_Format(t *testing.T) {
+	addr := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%s", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"},
+		{"%v", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"},
+		{"%q", `"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"`},
+		{"%8v", "Toke…Q5DA"},
+		{"%6v", "Tok…5DA"},
+		{"%100v", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"},
+		{"%#v", `pda.MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")`},
+		{"%x", "06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, addr); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+
+	if got := addr.Short(); got != "Toke…Q5DA" {
+		t.Errorf("Short() = %q", got)
+	}
+
+	// Outputs embedding an Address still print it as base58
+	out := ProgramDerivedAddressOutput{Address: addr, Bump: 255}
+	if got := fmt.Sprintf("%v", out); got != "{TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA 255}" {
+		t.Errorf("unexpected output formatting: %s", got)
+	}
+}
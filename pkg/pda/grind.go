@@ -0,0 +1,151 @@
+package pda
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mr-tron/base58"
+)
+
+// base58Alphabet is the Bitcoin/Solana base58 alphabet
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// GrindOption configures GrindKeypair
+type GrindOption func(*grindConfig)
+
+type grindConfig struct {
+	progress ProgressFunc
+	interval time.Duration
+
+	checkpoint         string
+	checkpointInterval time.Duration
+	resume             string
+}
+
+func newGrindConfig(opts []GrindOption) *grindConfig {
+	cfg := &grindConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithProgress calls fn every interval while a grind is running, and once
+// more when it finishes
+func WithProgress(fn ProgressFunc, interval time.Duration) GrindOption {
+	return func(c *grindConfig) {
+		c.progress = fn
+		c.interval = interval
+	}
+}
+
+// GrindKeypair searches for an ed25519 keypair whose base58 address starts
+// with prefix and ends with suffix (either may be empty), using workers
+// goroutines (GOMAXPROCS if workers < 1, limited by SetMaxConcurrency). It runs until a match is found or
+// ctx is cancelled. Every extra character multiplies the expected work by
+// 58, so keep patterns short.
+//
+// With WithCheckpoint or WithResume the search walks a deterministic key
+// space instead of drawing random keys, so it can be resumed; see
+// WithCheckpoint.
+func GrindKeypair(ctx context.Context, prefix, suffix string, workers int, opts ...GrindOption) (Keypair, error) {
+	if err := validatePattern(prefix + suffix); err != nil {
+		return Keypair{}, err
+	}
+
+	match := func(seed []byte) (Keypair, bool) {
+		priv := ed25519.NewKeyFromSeed(seed)
+		addr := base58.Encode(priv[ed25519.SeedSize:])
+		if strings.HasPrefix(addr, prefix) && strings.HasSuffix(addr, suffix) {
+			return Keypair{PrivateKey: priv}, true
+		}
+		return Keypair{}, false
+	}
+
+	cfg := newGrindConfig(opts)
+	workers = workerCount(workers, 0)
+
+	if cfg.checkpoint != "" || cfg.resume != "" {
+		job := GrindCheckpoint{Kind: "keypair", Prefix: prefix, Suffix: suffix}
+		return counterSearch(ctx, workers, cfg, job, func(_ int, base *[32]byte, n uint64) (Keypair, bool) {
+			seed := counterSeed(base, n)
+			return match(seed[:])
+		})
+	}
+
+	return parallelSearch(ctx, workers, cfg, func(int) (Keypair, bool) {
+		var seed [ed25519.SeedSize]byte
+		rand.Read(seed[:])
+		return match(seed[:])
+	})
+}
+
+// ErrInvalidPattern is returned by the grinders for a prefix or suffix no
+// base58 address can match
+type ErrInvalidPattern struct {
+	Reason string
+}
+
+func (e ErrInvalidPattern) Error() string {
+	return e.Reason
+}
+
+func (e ErrInvalidPattern) Code() ErrorCode {
+	return CodeInvalidArgument
+}
+
+// validatePattern rejects characters that can never appear in base58
+func validatePattern(pattern string) error {
+	for _, r := range pattern {
+		if !strings.ContainsRune(base58Alphabet, r) {
+			return ErrInvalidPattern{Reason: fmt.Sprintf("pattern character %q is not in the base58 alphabet", r)}
+		}
+	}
+	if len(pattern) > 44 {
+		return ErrInvalidPattern{Reason: fmt.Sprintf("pattern too long: %d characters (max: 44)", len(pattern))}
+	}
+	return nil
+}
+
+// parallelSearch calls try from workers goroutines until one reports a
+// match or ctx is done, reporting progress as configured. try receives the
+// index of the calling worker, in [0, workers).
+func parallelSearch[T any](ctx context.Context, workers int, cfg *grindConfig, try func(worker int) (T, bool)) (T, error) {
+	workers = workerCount(workers, 0)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		attempts = newProgress(cfg.progress, cfg.interval)
+		once     sync.Once
+		found    T
+		ok       bool
+	)
+
+	runWorkers(ctx, workers, func(w int) {
+		for ctx.Err() == nil {
+			res, match := try(w)
+			attempts.add(1)
+			if match {
+				once.Do(func() {
+					found, ok = res, true
+					cancel()
+				})
+				return
+			}
+		}
+	})
+	attempts.report()
+
+	if !ok {
+		var zero T
+		return zero, context.Cause(ctx)
+	}
+	return found, nil
+}
@@ -0,0 +1,60 @@
+package pda
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGrindKeypair(t *testing.T) {
+	var reports atomic.Int32
+	progress := func(done uint64, rate float64) {
+		reports.Add(1)
+	}
+
+	kp, err := GrindKeypair(context.Background(), "A", "", 2, WithProgress(progress, time.Millisecond))
+	if err != nil {
+		t.Fatalf("GrindKeypair failed: %v", err)
+	}
+
+	if !strings.HasPrefix(kp.Address().String(), "A") {
+		t.Errorf("address %s does not start with A", kp.Address())
+	}
+	if reports.Load() == 0 {
+		t.Error("expected at least one progress report")
+	}
+}
+
+func TestGrindKeypair_Suffix(t *testing.T) {
+	kp, err := GrindKeypair(context.Background(), "", "z", 0)
+	if err != nil {
+		t.Fatalf("GrindKeypair failed: %v", err)
+	}
+	if !strings.HasSuffix(kp.Address().String(), "z") {
+		t.Errorf("address %s does not end with z", kp.Address())
+	}
+}
+
+func TestGrindKeypair_InvalidPattern(t *testing.T) {
+	// 0, O, I and l are not in the base58 alphabet
+	for _, prefix := range []string{"0", "O", "I", "l"} {
+		_, err := GrindKeypair(context.Background(), prefix, "", 1)
+		if ErrorCodeOf(err) != CodeInvalidArgument {
+			t.Errorf("expected %s for prefix %q, got: %v", CodeInvalidArgument, prefix, err)
+		}
+	}
+}
+
+func TestGrindKeypair_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Practically impossible to find within the timeout
+	_, err := GrindKeypair(ctx, "zzzzzzzzzz", "", 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
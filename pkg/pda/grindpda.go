@@ -0,0 +1,164 @@
+package pda
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+)
+
+// VariableSeedKind selects how GrindPDA varies its last seed
+type VariableSeedKind int
+
+const (
+	// CounterU64LE tries 0, 1, 2, ... encoded as 8 little-endian bytes, the
+	// layout of a Rust u64 seed
+	CounterU64LE VariableSeedKind = iota
+	// RandomBytes tries random seeds of VariableSeed.Length bytes
+	RandomBytes
+)
+
+// VariableSeed describes the seed GrindPDA appends after the fixed seeds
+// and varies between attempts
+type VariableSeed struct {
+	Kind VariableSeedKind
+	// Length is the seed length for RandomBytes (1 to MaxSeedLength)
+	Length int
+}
+
+// Pattern is what a ground address must match. Prefix and Suffix are
+// literal base58; Regexp, if set, must also match the full base58 address.
+type Pattern struct {
+	Prefix string
+	Suffix string
+	Regexp *regexp.Regexp
+}
+
+// GrindPDAResult is a matching PDA and the variable seed that produced it
+type GrindPDAResult struct {
+	Seed   []byte                      `json:"seed"`
+	Output ProgramDerivedAddressOutput `json:"output"`
+}
+
+// GrindPDA searches for a PDA of program whose base58 address matches
+// pattern. Each candidate's seeds are fixedSeeds followed by one seed drawn
+// from variable; the canonical bump is used. It runs on workers goroutines
+// (GOMAXPROCS if workers < 1, limited by SetMaxConcurrency) until a match
+// is found or ctx is done, and honours WithProgress, WithCheckpoint and
+// WithResume. With a checkpoint, RandomBytes seeds come from a
+// deterministic sequence so the search can be resumed.
+func GrindPDA(ctx context.Context, program Address, fixedSeeds [][]byte, variable VariableSeed, pattern Pattern, workers int, opts ...GrindOption) (GrindPDAResult, error) {
+	if err := validatePattern(pattern.Prefix + pattern.Suffix); err != nil {
+		return GrindPDAResult{}, err
+	}
+
+	seedLen := 8
+	if variable.Kind == RandomBytes {
+		if variable.Length < 1 || variable.Length > MaxSeedLength {
+			return GrindPDAResult{}, fmt.Errorf("variable seed length must be between 1 and %d", MaxSeedLength)
+		}
+		seedLen = variable.Length
+	} else if variable.Kind != CounterU64LE {
+		return GrindPDAResult{}, fmt.Errorf("unknown variable seed kind %d", variable.Kind)
+	}
+
+	derive := newConfig(nil)
+	// The variable seed and the bump both count towards the limit
+	if err := validateSeeds(append(fixedSeeds[:len(fixedSeeds):len(fixedSeeds)], make([]byte, seedLen)), 1, derive); err != nil {
+		return GrindPDAResult{}, err
+	}
+
+	cfg := newGrindConfig(opts)
+	workers = workerCount(workers, 0)
+	programID := [32]byte(program)
+
+	// Per-worker seed lists and base58 buffers keep attempts allocation-free
+	type scratch struct {
+		seeds [][]byte
+		addr  []byte
+	}
+	scratches := make([]scratch, workers)
+	for w := range scratches {
+		seeds := append(fixedSeeds[:len(fixedSeeds):len(fixedSeeds)], make([]byte, seedLen))
+		scratches[w] = scratch{seeds: seeds, addr: make([]byte, 0, maxAddressLen)}
+	}
+
+	try := func(w int, fill func(seed []byte)) (GrindPDAResult, bool) {
+		s := &scratches[w]
+		seed := s.seeds[len(s.seeds)-1]
+		fill(seed)
+
+		digest, bump, err := findProgramAddress(&programID, s.seeds, derive)
+		if err != nil {
+			return GrindPDAResult{}, false
+		}
+		s.addr = AppendAddress(s.addr[:0], digest)
+		if !pattern.matches(s.addr) {
+			return GrindPDAResult{}, false
+		}
+		return GrindPDAResult{
+			Seed:   bytes.Clone(seed),
+			Output: ProgramDerivedAddressOutput{Address: Address(digest), Bump: bump},
+		}, true
+	}
+
+	if cfg.checkpoint != "" || cfg.resume != "" {
+		job := GrindCheckpoint{
+			Kind:   "pda",
+			Prefix: pattern.Prefix,
+			Suffix: pattern.Suffix,
+			Scope:  grindPDAScope(program, fixedSeeds, variable),
+		}
+		if pattern.Regexp != nil {
+			job.Regexp = pattern.Regexp.String()
+		}
+		// counterSearch hands out candidate numbers; map each to a seed
+		return counterSearch(ctx, workers, cfg, job, func(w int, base *[32]byte, n uint64) (GrindPDAResult, bool) {
+			return try(w, func(seed []byte) {
+				if variable.Kind == CounterU64LE {
+					binary.LittleEndian.PutUint64(seed, n)
+				} else {
+					full := counterSeed(base, n)
+					copy(seed, full[:])
+				}
+			})
+		})
+	}
+
+	if variable.Kind == CounterU64LE {
+		var counter atomic.Uint64
+		return parallelSearch(ctx, workers, cfg, func(w int) (GrindPDAResult, bool) {
+			n := counter.Add(1) - 1
+			return try(w, func(seed []byte) { binary.LittleEndian.PutUint64(seed, n) })
+		})
+	}
+	return parallelSearch(ctx, workers, cfg, func(w int) (GrindPDAResult, bool) {
+		return try(w, func(seed []byte) { rand.Read(seed) })
+	})
+}
+
+// matches reports whether the base58 address matches the pattern
+func (p Pattern) matches(addr []byte) bool {
+	if !bytes.HasPrefix(addr, []byte(p.Prefix)) || !bytes.HasSuffix(addr, []byte(p.Suffix)) {
+		return false
+	}
+	return p.Regexp == nil || p.Regexp.Match(addr)
+}
+
+// grindPDAScope fingerprints a PDA grind's program and seed layout for its
+// checkpoint
+func grindPDAScope(program Address, fixedSeeds [][]byte, variable VariableSeed) string {
+	h := sha256.New()
+	h.Write(program[:])
+	for _, seed := range fixedSeeds {
+		binary.Write(h, binary.LittleEndian, uint32(len(seed)))
+		h.Write(seed)
+	}
+	binary.Write(h, binary.LittleEndian, [2]int32{int32(variable.Kind), int32(variable.Length)})
+	return hex.EncodeToString(h.Sum(nil))
+}
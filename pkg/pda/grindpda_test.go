@@ -0,0 +1,110 @@
+package pda
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGrindPDA_Counter(t *testing.T) {
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	fixed := [][]byte{[]byte("vanity")}
+
+	res, err := GrindPDA(context.Background(), program, fixed, VariableSeed{Kind: CounterU64LE}, Pattern{Prefix: "A"}, 2)
+	if err != nil {
+		t.Fatalf("GrindPDA failed: %v", err)
+	}
+	if !strings.HasPrefix(res.Output.Address.String(), "A") {
+		t.Errorf("address %s does not start with A", res.Output.Address)
+	}
+	if len(res.Seed) != 8 {
+		t.Fatalf("expected an 8-byte counter seed, got %d bytes", len(res.Seed))
+	}
+
+	// The result must be the canonical PDA for the fixed and variable seeds
+	want, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: program,
+		Seeds:          [][]byte{fixed[0], res.Seed},
+	})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if want != res.Output {
+		t.Errorf("got %+v, want %+v (counter %d)", res.Output, want, binary.LittleEndian.Uint64(res.Seed))
+	}
+}
+
+func TestGrindPDA_RandomRegexp(t *testing.T) {
+	program := MustNewAddress("11111111111111111111111111111111")
+	pattern := Pattern{Suffix: "x", Regexp: regexp.MustCompile(`^[1-9]`)}
+
+	res, err := GrindPDA(context.Background(), program, nil, VariableSeed{Kind: RandomBytes, Length: 12}, pattern, 0)
+	if err != nil {
+		t.Fatalf("GrindPDA failed: %v", err)
+	}
+	addr := res.Output.Address.String()
+	if !strings.HasSuffix(addr, "x") || !pattern.Regexp.MatchString(addr) {
+		t.Errorf("address %s does not match the pattern", addr)
+	}
+	if len(res.Seed) != 12 {
+		t.Errorf("expected a 12-byte seed, got %d bytes", len(res.Seed))
+	}
+}
+
+func TestGrindPDA_Checkpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grind.json")
+	program := MustNewAddress("11111111111111111111111111111111")
+	pattern := Pattern{Prefix: "zzzzz"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := GrindPDA(ctx, program, nil, VariableSeed{Kind: CounterU64LE}, pattern, 2, WithCheckpoint(path, time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+
+	cp, err := LoadGrindCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadGrindCheckpoint failed: %v", err)
+	}
+	if cp.Kind != "pda" || cp.Scope == "" || cp.Next == 0 {
+		t.Errorf("unexpected checkpoint: %+v", cp)
+	}
+
+	// The same pattern with different fixed seeds is a different job
+	if _, err := GrindPDA(context.Background(), program, [][]byte{[]byte("other")}, VariableSeed{Kind: CounterU64LE}, pattern, 1, WithResume(path)); err == nil {
+		t.Error("expected an error resuming a checkpoint for different seeds")
+	}
+}
+
+func TestGrindPDA_Errors(t *testing.T) {
+	program := MustNewAddress("11111111111111111111111111111111")
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		fixed    [][]byte
+		variable VariableSeed
+		pattern  Pattern
+	}{
+		{"invalid pattern", nil, VariableSeed{}, Pattern{Prefix: "0"}},
+		{"zero length", nil, VariableSeed{Kind: RandomBytes}, Pattern{}},
+		{"too long", nil, VariableSeed{Kind: RandomBytes, Length: MaxSeedLength + 1}, Pattern{}},
+		{"unknown kind", nil, VariableSeed{Kind: 7}, Pattern{}},
+		{"too many seeds", make([][]byte, MaxSeeds-1), VariableSeed{}, Pattern{}},
+		{"fixed seed too long", [][]byte{make([]byte, MaxSeedLength+1)}, VariableSeed{}, Pattern{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GrindPDA(ctx, program, tt.fixed, tt.variable, tt.pattern, 1); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package pda
+
+import "crypto/sha256"
+
+// maxPreimage is the largest hash input FindPDAInto builds with the default
+// limits: all seeds (bump included) followed by the program ID and marker.
+const maxPreimage = MaxSeeds*MaxSeedLength + 32 + len("ProgramDerivedAddress")
+
+// FindPDAInto finds the canonical PDA for seeds under the raw program ID and
+// writes it into dst. It uses the default marker and limits, performs no
+// heap allocation on success and never base58-encodes, which makes it the
+// building block for grinding and other allocation-sensitive loops.
+func FindPDAInto(dst *[32]byte, program [32]byte, seeds [][]byte) (bump uint8, err error) {
+	cfg := config{maxSeeds: MaxSeeds, maxSeedLength: MaxSeedLength}
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(seeds, 1, &cfg); err != nil {
+		return 0, err
+	}
+
+	// Lay out seeds || bump || programID || marker once; only the bump
+	// byte changes between attempts
+	var buf [maxPreimage]byte
+	n := 0
+	for _, seed := range seeds {
+		n += copy(buf[n:], seed)
+	}
+	bumpAt := n
+	n++
+	n += copy(buf[n:], program[:])
+	n += copy(buf[n:], pdaMarkerBytes)
+
+	for b := 255; b >= 0; b-- {
+		buf[bumpAt] = uint8(b)
+		digest := sha256.Sum256(buf[:n])
+		if isValidPoint(&digest) {
+			continue
+		}
+		*dst = digest
+		return uint8(b), nil
+	}
+
+	return 0, ErrNoViableBump{}
+}
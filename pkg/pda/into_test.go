@@ -0,0 +1,82 @@
+package pda
+
+import (
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestFindPDAInto_MatchesGetProgramDerivedAddress(t *testing.T) {
+	programAddr, err := NewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+	program, _ := programAddr.ToBytes()
+
+	seeds := [][]byte{[]byte("into"), make([]byte, MaxSeedLength)}
+
+	var dst [32]byte
+	bump, err := FindPDAInto(&dst, program, seeds)
+	if err != nil {
+		t.Fatalf("FindPDAInto failed: %v", err)
+	}
+
+	want, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: programAddr,
+		Seeds:          seeds,
+	})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	if bump != want.Bump || Address(dst) != want.Address {
+		t.Errorf("got (%s, %d), want (%s, %d)", AddressFromBytes(dst), bump, want.Address, want.Bump)
+	}
+}
+
+func TestFindPDAInto_NoAllocations(t *testing.T) {
+	program, _ := DecodeAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	seeds := [][]byte{[]byte("zero-alloc"), {1, 2, 3}}
+
+	var dst [32]byte
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := FindPDAInto(&dst, program, seeds); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations, got %v", allocs)
+	}
+}
+
+func TestFindPDAInto_TooManySeeds(t *testing.T) {
+	var dst [32]byte
+	_, err := FindPDAInto(&dst, [32]byte{}, make([][]byte, MaxSeeds))
+	if err == nil {
+		t.Error("expected error for too many seeds")
+	}
+}
+
+// setBytesOnCurve is the reference check isValidPoint replaces: a full
+// point decompression
+func setBytesOnCurve(b *[32]byte) bool {
+	_, err := new(edwards25519.Point).SetBytes(b[:])
+	return err == nil
+}
+
+func TestIsValidPoint_MatchesSetBytes(t *testing.T) {
+	edges := [][32]byte{{}, {1}, {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}}
+	for _, b := range edges {
+		if got, want := isValidPoint(&b), setBytesOnCurve(&b); got != want {
+			t.Errorf("%x: isValidPoint = %v, SetBytes = %v", b, got, want)
+		}
+	}
+
+	for i := 0; i < 2000; i++ {
+		digest := hashPDA([][]byte{SeedU64LE(uint64(i))}, nil, &[32]byte{}, pdaMarkerBytes)
+		if got, want := isValidPoint(&digest), setBytesOnCurve(&digest); got != want {
+			t.Fatalf("%x: isValidPoint = %v, SetBytes = %v", digest, got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package pda
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Keypair is an ed25519 keypair. Its public key is an on-curve Solana
+// address, usable as a program ID, owner or seed.
+type Keypair struct {
+	PrivateKey ed25519.PrivateKey
+}
+
+// GenerateKeypair creates a new random keypair
+func GenerateKeypair() (Keypair, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Keypair{}, err
+	}
+	return Keypair{PrivateKey: priv}, nil
+}
+
+// PublicKey returns the ed25519 public key of the keypair
+func (k Keypair) PublicKey() ed25519.PublicKey {
+	return k.PrivateKey.Public().(ed25519.PublicKey)
+}
+
+// Address returns the Solana address of the keypair
+func (k Keypair) Address() Address {
+	return AddressFromPublicKey(k.PublicKey())
+}
+
+// AddressFromPublicKey converts an ed25519 public key to an Address. Like
+// the crypto/ed25519 functions, it panics if the key is not 32 bytes.
+func AddressFromPublicKey(pub ed25519.PublicKey) Address {
+	if len(pub) != ed25519.PublicKeySize {
+		panic(fmt.Sprintf("pda: bad ed25519 public key length: %d", len(pub)))
+	}
+	return Address(pub)
+}
+
+// --- solana-keygen files ---
+
+// LoadKeypairFile reads a keypair in the solana-keygen id.json format: a
+// JSON array of 64 bytes holding the 32-byte secret seed followed by the
+// 32-byte public key.
+func LoadKeypairFile(path string) (Keypair, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Keypair{}, err
+	}
+
+	// Decode into []int so the values are not mistaken for base64
+	var raw []int
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Keypair{}, fmt.Errorf("keypair file %s: %w", path, err)
+	}
+	if len(raw) != ed25519.PrivateKeySize {
+		return Keypair{}, fmt.Errorf("keypair file %s: expected %d bytes, got %d", path, ed25519.PrivateKeySize, len(raw))
+	}
+
+	key := make([]byte, len(raw))
+	for i, v := range raw {
+		if v < 0 || v > 255 {
+			return Keypair{}, fmt.Errorf("keypair file %s: byte %d out of range: %d", path, i, v)
+		}
+		key[i] = byte(v)
+	}
+
+	// Reject files whose public half does not match the secret seed
+	priv := ed25519.NewKeyFromSeed(key[:ed25519.SeedSize])
+	if !bytes.Equal(priv[ed25519.SeedSize:], key[ed25519.SeedSize:]) {
+		return Keypair{}, fmt.Errorf("keypair file %s: public key does not match secret key", path)
+	}
+
+	return Keypair{PrivateKey: priv}, nil
+}
+
+// SaveKeypairFile writes kp in the solana-keygen id.json format. The file is
+// created with 0600 permissions since it holds the secret key, and never
+// replaces an existing one: if path exists it fails with an error matching
+// fs.ErrExist.
+func SaveKeypairFile(path string, kp Keypair) error {
+	if len(kp.PrivateKey) != ed25519.PrivateKeySize {
+		return fmt.Errorf("bad ed25519 private key length: %d", len(kp.PrivateKey))
+	}
+
+	raw := make([]int, len(kp.PrivateKey))
+	for i, b := range kp.PrivateKey {
+		raw[i] = int(b)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,137 @@
+package pda
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateKeypair(t *testing.T) {
+	kp, err := GenerateKeypair()
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+
+	addr := kp.Address()
+	if !bytes.Equal(addr[:], kp.PublicKey()) {
+		t.Errorf("address %s does not match public key %x", addr, kp.PublicKey())
+	}
+
+	onCurve, err := addr.IsOnCurve()
+	if err != nil || !onCurve {
+		t.Errorf("expected keypair address to be on the curve (err: %v)", err)
+	}
+
+	other, err := GenerateKeypair()
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+	if other.Address() == addr {
+		t.Error("two generated keypairs share an address")
+	}
+
+	// Generated addresses work as program IDs
+	if _, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: addr,
+		Seeds:          [][]byte{[]byte("owner"), []byte(other.PublicKey())},
+	}); err != nil {
+		t.Errorf("GetProgramDerivedAddress failed: %v", err)
+	}
+}
+
+func TestAddressFromPublicKey_BadLength(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for short public key")
+		}
+	}()
+	AddressFromPublicKey(make([]byte, 31))
+}
+
+func TestKeypairFile_RoundTrip(t *testing.T) {
+	kp, err := GenerateKeypair()
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "id.json")
+	if err := SaveKeypairFile(path, kp); err != nil {
+		t.Fatalf("SaveKeypairFile failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected 0600 permissions, got %o", perm)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "[") {
+		t.Errorf("expected JSON array, got %s", data)
+	}
+
+	loaded, err := LoadKeypairFile(path)
+	if err != nil {
+		t.Fatalf("LoadKeypairFile failed: %v", err)
+	}
+	if loaded.Address() != kp.Address() {
+		t.Errorf("loaded address %s, want %s", loaded.Address(), kp.Address())
+	}
+}
+
+func TestSaveKeypairFile_Exists(t *testing.T) {
+	kp, err := GenerateKeypair()
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "id.json")
+	if err := os.WriteFile(path, []byte("keep me"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveKeypairFile(path, kp); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("expected fs.ErrExist, got: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "keep me" {
+		t.Errorf("existing file was overwritten: %s", data)
+	}
+}
+
+func TestLoadKeypairFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	kp, err := GenerateKeypair()
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+	mismatched := make([]int, 64)
+	for i, b := range kp.PrivateKey {
+		mismatched[i] = int(b)
+	}
+	mismatched[63] ^= 1
+	mismatchedJSON, _ := json.Marshal(mismatched)
+
+	files := map[string]string{
+		"short.json":      "[1,2,3]",
+		"range.json":      "[" + strings.Repeat("256,", 63) + "256]",
+		"notjson.json":    "not json",
+		"mismatched.json": string(mismatchedJSON),
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if _, err := LoadKeypairFile(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package pda
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SolanaDerivationPath is the path Phantom, Solflare and
+// solana-keygen --derivation-path use for the first account
+const SolanaDerivationPath = "m/44'/501'/0'/0'"
+
+// hardenedOffset marks a hardened SLIP-0010 child index
+const hardenedOffset = 1 << 31
+
+// KeypairFromMnemonic derives a keypair from a BIP39 mnemonic and optional
+// passphrase. The mnemonic's checksum is validated against the English
+// wordlist.
+//
+// path is a SLIP-0010 ed25519 derivation path such as SolanaDerivationPath
+// or "m/44'/501'/3'/0'". Only hardened segments are allowed (ed25519 has no
+// public derivation); "h" is accepted as an alternative to "'". An empty
+// path reproduces plain `solana-keygen recover`, which uses the first 32
+// bytes of the BIP39 seed directly.
+func KeypairFromMnemonic(mnemonic, passphrase, path string) (Keypair, error) {
+	mnemonic = strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	passphrase = norm.NFKD.String(passphrase)
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return Keypair{}, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	if path == "" {
+		return Keypair{PrivateKey: ed25519.NewKeyFromSeed(seed[:ed25519.SeedSize])}, nil
+	}
+
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return Keypair{}, err
+	}
+
+	key, chainCode := slip10Master(seed)
+	for _, index := range indexes {
+		key, chainCode = slip10Child(key, chainCode, index)
+	}
+
+	return Keypair{PrivateKey: ed25519.NewKeyFromSeed(key)}, nil
+}
+
+// parseDerivationPath parses "m/44'/501'/0'/0'" into hardened child indexes
+func parseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q must start with m", path)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, seg := range segments[1:] {
+		trimmed := strings.TrimRight(seg, "'h")
+		if len(seg)-len(trimmed) != 1 {
+			return nil, fmt.Errorf("derivation path %q: segment %q must be hardened", path, seg)
+		}
+
+		n, err := strconv.ParseUint(trimmed, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("derivation path %q: segment %q: %w", path, seg, err)
+		}
+		indexes = append(indexes, uint32(n)+hardenedOffset)
+	}
+
+	return indexes, nil
+}
+
+// slip10Master derives the ed25519 master key and chain code from a seed
+func slip10Master(seed []byte) (key, chainCode []byte) {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// slip10Child derives the hardened child at index
+func slip10Child(key, chainCode []byte, index uint32) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write([]byte{0})
+	mac.Write(key)
+	mac.Write(binary.BigEndian.AppendUint32(nil, index))
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,88 @@
+package pda
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestSLIP10_TestVector1(t *testing.T) {
+	// SLIP-0010 test vector 1 for ed25519
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	key, chainCode := slip10Master(seed)
+	if got := hex.EncodeToString(key); got != "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7" {
+		t.Errorf("master key = %s", got)
+	}
+	if got := hex.EncodeToString(chainCode); got != "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb" {
+		t.Errorf("master chain code = %s", got)
+	}
+
+	key, _ = slip10Child(key, chainCode, hardenedOffset)
+	if got := hex.EncodeToString(key); got != "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3" {
+		t.Errorf("m/0H key = %s", got)
+	}
+}
+
+func TestKeypairFromMnemonic(t *testing.T) {
+	kp, err := KeypairFromMnemonic(testMnemonic, "", SolanaDerivationPath)
+	if err != nil {
+		t.Fatalf("KeypairFromMnemonic failed: %v", err)
+	}
+
+	// First account Phantom and solana-keygen derive for this mnemonic
+	if got := kp.Address().String(); got != "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk" {
+		t.Errorf("unexpected address: %s", got)
+	}
+
+	// Same mnemonic with extra whitespace and the "h" hardened notation
+	again, err := KeypairFromMnemonic("  "+testMnemonic+"\n", "", "m/44h/501h/0h/0h")
+	if err != nil {
+		t.Fatalf("KeypairFromMnemonic failed: %v", err)
+	}
+	if again.Address() != kp.Address() {
+		t.Errorf("equivalent inputs derived %s and %s", again.Address(), kp.Address())
+	}
+
+	other, err := KeypairFromMnemonic(testMnemonic, "", "m/44'/501'/1'/0'")
+	if err != nil {
+		t.Fatalf("KeypairFromMnemonic failed: %v", err)
+	}
+	if other.Address() == kp.Address() {
+		t.Error("different accounts derived the same address")
+	}
+
+	withPassphrase, err := KeypairFromMnemonic(testMnemonic, "TREZOR", SolanaDerivationPath)
+	if err != nil {
+		t.Fatalf("KeypairFromMnemonic failed: %v", err)
+	}
+	if withPassphrase.Address() == kp.Address() {
+		t.Error("passphrase did not change the derived address")
+	}
+}
+
+func TestKeypairFromMnemonic_NoPath(t *testing.T) {
+	// BIP39 test vector: the seed for testMnemonic with passphrase TREZOR
+	seed, _ := hex.DecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+
+	kp, err := KeypairFromMnemonic(testMnemonic, "TREZOR", "")
+	if err != nil {
+		t.Fatalf("KeypairFromMnemonic failed: %v", err)
+	}
+	if got := hex.EncodeToString(kp.PrivateKey.Seed()); got != hex.EncodeToString(seed[:32]) {
+		t.Errorf("expected the first 32 seed bytes, got %s", got)
+	}
+}
+
+func TestKeypairFromMnemonic_Invalid(t *testing.T) {
+	if _, err := KeypairFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "", SolanaDerivationPath); err == nil {
+		t.Error("expected checksum error")
+	}
+
+	for _, path := range []string{"44'/501'", "m/44'/501'/0", "m/44'/x'", "m/44''"} {
+		if _, err := KeypairFromMnemonic(testMnemonic, "", path); err == nil {
+			t.Errorf("expected error for path %q", path)
+		}
+	}
+}
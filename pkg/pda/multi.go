@@ -0,0 +1,44 @@
+package pda
+
+import "fmt"
+
+// FindPDAMulti derives the PDA of the same seeds under each of programs,
+// returning one result per program in order. The seeds come first in the
+// preimage, so they are hashed once and the saved midstate is shared by
+// every program and bump.
+func FindPDAMulti(programs []Address, seeds [][]byte, opts ...Option) ([]ProgramDerivedAddressOutput, error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(seeds, 1, cfg); err != nil {
+		return nil, err
+	}
+
+	outputs := make([]ProgramDerivedAddressOutput, len(programs))
+
+	// The cache and the parallel search manage their own hashing
+	if cfg.cache != nil || cfg.parallel > 1 {
+		for i, program := range programs {
+			out, err := deriveValidated(ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds}, cfg)
+			if err != nil {
+				return nil, fmt.Errorf("program %s: %w", program, err)
+			}
+			outputs[i] = out
+		}
+		return outputs, nil
+	}
+
+	var programID [32]byte
+	bh := newBumpHasher(&programID, seeds, cfg.marker)
+	defer bh.release()
+
+	for i, program := range programs {
+		programID = [32]byte(program)
+		digest, bump, err := searchBumpsWith(bh, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("program %s: %w", program, err)
+		}
+		outputs[i] = ProgramDerivedAddressOutput{Address: Address(digest), Bump: bump}
+	}
+	return outputs, nil
+}
@@ -0,0 +1,72 @@
+package pda
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindPDAMulti(t *testing.T) {
+	programs := []Address{
+		MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		MustNewAddress("11111111111111111111111111111111"),
+		MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111"),
+	}
+	seeds := [][]byte{[]byte("multi"), []byte("program")}
+
+	for _, opts := range [][]Option{nil, {WithCache(NewLRUCache(8))}, {WithParallelBumps(4)}} {
+		outputs, err := FindPDAMulti(programs, seeds, opts...)
+		if err != nil {
+			t.Fatalf("FindPDAMulti failed: %v", err)
+		}
+		if len(outputs) != len(programs) {
+			t.Fatalf("expected %d results, got %d", len(programs), len(outputs))
+		}
+
+		for i, program := range programs {
+			want, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds})
+			if err != nil {
+				t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+			}
+			if outputs[i] != want {
+				t.Errorf("program %s: got %+v, want %+v", program, outputs[i], want)
+			}
+		}
+	}
+}
+
+func TestFindPDAMulti_Errors(t *testing.T) {
+	programs := []Address{MustNewAddress("11111111111111111111111111111111")}
+
+	_, err := FindPDAMulti(programs, [][]byte{make([]byte, MaxSeedLength+1)})
+	var seedTooLongErr ErrSeedTooLong
+	if !errors.As(err, &seedTooLongErr) {
+		t.Errorf("expected ErrSeedTooLong, got: %v", err)
+	}
+
+	// A single on-curve bump leaves no viable bump
+	out, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{ProgramAddress: programs[0], Seeds: [][]byte{[]byte("multi")}})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if out.Bump == 255 {
+		t.Skip("canonical bump is 255, no on-curve bump above it")
+	}
+	_, err = FindPDAMulti(programs, [][]byte{[]byte("multi")}, WithBumpRange(255, 255))
+	if !errors.Is(err, ErrNoViableBump{}) {
+		t.Errorf("expected ErrNoViableBump, got: %v", err)
+	}
+}
+
+func BenchmarkFindPDAMulti(b *testing.B) {
+	programs := make([]Address, 16)
+	for i := range programs {
+		programs[i][0] = byte(i)
+	}
+	seeds := [][]byte{make([]byte, 32), make([]byte, 32), make([]byte, 32)}
+
+	for b.Loop() {
+		if _, err := FindPDAMulti(programs, seeds); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
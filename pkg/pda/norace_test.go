@@ -0,0 +1,5 @@
+//go:build !race
+
+package pda
+
+const raceEnabled = false
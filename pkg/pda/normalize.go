@@ -0,0 +1,65 @@
+package pda
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizationForm selects the Unicode normalization applied to string
+// seeds. The same text can be encoded as different bytes (a precomposed "é"
+// versus "e" plus a combining accent), and each encoding derives a different
+// PDA, so callers that accept user-typed seeds should pick one form.
+type NormalizationForm int
+
+const (
+	// NoNormalization hashes the string's bytes unchanged (the default)
+	NoNormalization NormalizationForm = iota
+	// NFC composes characters canonically
+	NFC
+	// NFKC composes characters and folds compatibility variants such as
+	// ligatures and full-width forms
+	NFKC
+)
+
+func (f NormalizationForm) String() string {
+	switch f {
+	case NoNormalization:
+		return "none"
+	case NFC:
+		return "NFC"
+	case NFKC:
+		return "NFKC"
+	}
+	return fmt.Sprintf("NormalizationForm(%d)", int(f))
+}
+
+// ParseNormalizationForm parses "none", "nfc" or "nfkc" (case-insensitive).
+// An empty string means NoNormalization.
+func ParseNormalizationForm(s string) (NormalizationForm, error) {
+	switch strings.ToLower(s) {
+	case "", "none":
+		return NoNormalization, nil
+	case "nfc":
+		return NFC, nil
+	case "nfkc":
+		return NFKC, nil
+	}
+	return NoNormalization, fmt.Errorf("unknown normalization form %q", s)
+}
+
+// NormalizeSeed returns the bytes of s in the given form and reports whether
+// normalization changed them
+func NormalizeSeed(s string, form NormalizationForm) ([]byte, bool) {
+	var out string
+	switch form {
+	case NFC:
+		out = norm.NFC.String(s)
+	case NFKC:
+		out = norm.NFKC.String(s)
+	default:
+		out = s
+	}
+	return []byte(out), out != s
+}
@@ -0,0 +1,63 @@
+package pda
+
+import (
+	"bytes"
+	"testing"
+)
+
+const (
+	composedE   = "caf\u00e9"
+	decomposedE = "cafe\u0301"
+)
+
+func TestNormalizeSeed(t *testing.T) {
+	tests := []struct {
+		in      string
+		form    NormalizationForm
+		want    string
+		changed bool
+	}{
+		{decomposedE, NoNormalization, decomposedE, false},
+		{decomposedE, NFC, composedE, true},
+		{composedE, NFC, composedE, false},
+		{"\ufb01le", NFC, "\ufb01le", false},
+		{"\ufb01le", NFKC, "file", true},
+	}
+
+	for _, tt := range tests {
+		got, changed := NormalizeSeed(tt.in, tt.form)
+		if !bytes.Equal(got, []byte(tt.want)) || changed != tt.changed {
+			t.Errorf("NormalizeSeed(%q, %v) = %q, %v; want %q, %v", tt.in, tt.form, got, changed, tt.want, tt.changed)
+		}
+	}
+}
+
+func TestNormalizeSeed_SamePDA(t *testing.T) {
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	derive := func(s string) Address {
+		seed, _ := NormalizeSeed(s, NFC)
+		out, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{ProgramAddress: program, Seeds: [][]byte{seed}})
+		if err != nil {
+			t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+		}
+		return out.Address
+	}
+
+	if derive(composedE) != derive(decomposedE) {
+		t.Error("expected composed and decomposed seeds to derive the same PDA after NFC")
+	}
+}
+
+func TestParseNormalizationForm(t *testing.T) {
+	for in, want := range map[string]NormalizationForm{"": NoNormalization, "none": NoNormalization, "NFC": NFC, "nfkc": NFKC} {
+		got, err := ParseNormalizationForm(in)
+		if err != nil || got != want {
+			t.Errorf("ParseNormalizationForm(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+
+	if _, err := ParseNormalizationForm("nfd"); err == nil {
+		t.Error("expected an error for an unsupported form")
+	}
+}
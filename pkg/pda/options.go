@@ -0,0 +1,137 @@
+package pda
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+)
+
+// Option configures a derivation call (or a Deriver)
+type Option func(*config)
+
+type config struct {
+	marker        []byte
+	maxSeeds      int
+	maxSeedLength int
+	bumpStart     uint8
+	bumpEnd       uint8
+	strict        bool
+	trace         TraceHook
+	logger        *slog.Logger
+	cache         Cache
+	parallel      int
+}
+
+// newConfig applies opts on top of the Solana defaults
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		marker:        pdaMarkerBytes,
+		maxSeeds:      MaxSeeds,
+		maxSeedLength: MaxSeedLength,
+		bumpStart:     255,
+		bumpEnd:       0,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithMarker replaces the "ProgramDerivedAddress" domain-separation marker
+// that is hashed after the program ID. SVM forks that use a different
+// marker can target their own PDAs with it.
+func WithMarker(marker []byte) Option {
+	return func(c *config) {
+		c.marker = append([]byte(nil), marker...)
+	}
+}
+
+// WithLimits overrides MaxSeeds and MaxSeedLength for runtimes with
+// different limits. As with Solana, maxSeeds counts the bump seed. It panics
+// if either limit is less than 1, which no derivation could satisfy.
+func WithLimits(maxSeeds, maxSeedLength int) Option {
+	if maxSeeds < 1 || maxSeedLength < 1 {
+		panic(fmt.Sprintf("pda: invalid limits: %d seeds of %d bytes", maxSeeds, maxSeedLength))
+	}
+	return func(c *config) {
+		c.maxSeeds = maxSeeds
+		c.maxSeedLength = maxSeedLength
+	}
+}
+
+// WithBumpRange restricts the bump search to start..end (inclusive). The
+// search begins at start and walks towards end, so WithBumpRange(200, 0)
+// resumes a descending search and WithBumpRange(0, 255) ascends.
+func WithBumpRange(start, end uint8) Option {
+	return func(c *config) {
+		c.bumpStart = start
+		c.bumpEnd = end
+	}
+}
+
+// WithAscendingBumps searches the bump range from its lowest value upwards,
+// so the lowest off-curve bump is returned instead of the canonical (highest)
+// one. Some older programs stored that bump on-chain. It reverses whatever
+// range earlier options configured, so place it after WithBumpRange or
+// WithStrict.
+func WithAscendingBumps() Option {
+	return func(c *config) {
+		if c.bumpStart > c.bumpEnd {
+			c.bumpStart, c.bumpEnd = c.bumpEnd, c.bumpStart
+		}
+	}
+}
+
+// TraceHook is called for every bump attempt with the resulting hash and
+// whether it landed on the curve
+type TraceHook func(bump uint8, digest [32]byte, onCurve bool)
+
+// WithTraceHook installs fn to observe each bump attempt, for logging,
+// metrics or research tooling. Batch derivations call fn from several
+// goroutines at once, so it must be safe for concurrent use there.
+func WithTraceHook(fn TraceHook) Option {
+	return func(c *config) {
+		c.trace = fn
+	}
+}
+
+// WithLogger emits debug-level events to logger for seed validation
+// failures and bump search results. Nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithCache memoizes bump searches in cache, e.g. an LRUCache or a store
+// of your own. A cache hit skips the search, so the trace hook is not
+// called for it. Share one cache between calls (and goroutines) to benefit
+// from it.
+func WithCache(cache Cache) Option {
+	return func(c *config) {
+		c.cache = cache
+	}
+}
+
+// debug logs msg at debug level when a logger is configured
+func (c *config) debug(msg string, args ...any) {
+	if c.logger != nil {
+		c.logger.Log(context.Background(), slog.LevelDebug, msg, args...)
+	}
+}
+
+// bumps yields the candidate bump seeds in search order
+func (c *config) bumps() iter.Seq[uint8] {
+	return func(yield func(uint8) bool) {
+		step := -1
+		if c.bumpStart < c.bumpEnd {
+			step = 1
+		}
+		for b := int(c.bumpStart); ; b += step {
+			if !yield(uint8(b)) || b == int(c.bumpEnd) {
+				return
+			}
+		}
+	}
+}
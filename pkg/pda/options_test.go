@@ -0,0 +1,277 @@
+package pda
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWithMarker_ChangesResult(t *testing.T) {
+	programAddr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: programAddr,
+		Seeds:          [][]byte{[]byte("marker-test")},
+	}
+
+	solana, err := GetProgramDerivedAddress(input)
+	if err != nil {
+		t.Fatalf("default marker failed: %v", err)
+	}
+
+	explicit, err := GetProgramDerivedAddress(input, WithMarker([]byte("ProgramDerivedAddress")))
+	if err != nil {
+		t.Fatalf("explicit marker failed: %v", err)
+	}
+
+	if explicit != solana {
+		t.Errorf("explicit default marker changed the result: %+v != %+v", explicit, solana)
+	}
+
+	fork, err := GetProgramDerivedAddress(input, WithMarker([]byte("ForkDerivedAddress")))
+	if err != nil {
+		t.Fatalf("custom marker failed: %v", err)
+	}
+
+	if fork.Address == solana.Address {
+		t.Error("custom marker produced the same address as the Solana marker")
+	}
+
+	// The returned bump must reproduce the address under the same marker
+	verify, err := CreateProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: programAddr,
+		Seeds:          [][]byte{[]byte("marker-test"), {fork.Bump}},
+	}, WithMarker([]byte("ForkDerivedAddress")))
+	if err != nil {
+		t.Fatalf("CreateProgramDerivedAddress failed: %v", err)
+	}
+
+	if verify != fork.Address {
+		t.Errorf("addresses don't match: %s != %s", verify, fork.Address)
+	}
+}
+
+func TestWithLimits(t *testing.T) {
+	programAddr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+
+	longSeed := make([]byte, MaxSeedLength+8)
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: programAddr,
+		Seeds:          [][]byte{longSeed},
+	}
+
+	// Default limits reject the seed
+	_, err = GetProgramDerivedAddress(input)
+	var seedTooLongErr ErrSeedTooLong
+	if !errors.As(err, &seedTooLongErr) {
+		t.Fatalf("expected ErrSeedTooLong with default limits, got: %v", err)
+	}
+
+	// Relaxed limits accept it
+	if _, err := GetProgramDerivedAddress(input, WithLimits(MaxSeeds, 64)); err != nil {
+		t.Errorf("expected relaxed limits to accept the seed, got: %v", err)
+	}
+
+	// Tighter seed count is enforced (bump seed counts towards the limit)
+	input.Seeds = [][]byte{[]byte("a"), []byte("b")}
+	_, err = GetProgramDerivedAddress(input, WithLimits(2, MaxSeedLength))
+
+	var maxSeedsErr ErrMaxSeedsExceeded
+	if !errors.As(err, &maxSeedsErr) {
+		t.Fatalf("expected ErrMaxSeedsExceeded, got: %v", err)
+	}
+	if maxSeedsErr.Max != 2 {
+		t.Errorf("expected error to report max 2, got %d", maxSeedsErr.Max)
+	}
+}
+
+func TestWithLimits_Invalid(t *testing.T) {
+	for _, limits := range [][2]int{{0, MaxSeedLength}, {MaxSeeds, 0}, {-1, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("WithLimits(%d, %d): expected a panic", limits[0], limits[1])
+				}
+			}()
+			WithLimits(limits[0], limits[1])
+		}()
+	}
+}
+
+func TestWithBumpRange(t *testing.T) {
+	programAddr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: programAddr,
+		Seeds:          [][]byte{[]byte("bump-range")},
+	}
+
+	canonical, err := GetProgramDerivedAddress(input)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	// Starting exactly at the canonical bump finds it again
+	same, err := GetProgramDerivedAddress(input, WithBumpRange(canonical.Bump, 0))
+	if err != nil {
+		t.Fatalf("search from canonical bump failed: %v", err)
+	}
+	if same != canonical {
+		t.Errorf("got %+v, want %+v", same, canonical)
+	}
+
+	// Resuming below the canonical bump yields a lower, non-canonical bump
+	if canonical.Bump == 0 {
+		t.Skip("canonical bump is 0, nothing below it")
+	}
+	lower, err := GetProgramDerivedAddress(input, WithBumpRange(canonical.Bump-1, 0))
+	if err != nil {
+		t.Fatalf("search below canonical bump failed: %v", err)
+	}
+	if lower.Bump >= canonical.Bump {
+		t.Errorf("expected bump below %d, got %d", canonical.Bump, lower.Bump)
+	}
+
+	// A single on-curve bump yields no result
+	for bump := 255; bump > int(canonical.Bump); bump-- {
+		_, err := GetProgramDerivedAddress(input, WithBumpRange(uint8(bump), uint8(bump)))
+		if err == nil {
+			t.Errorf("bump %d is above the canonical bump and should be on-curve", bump)
+		}
+	}
+}
+
+func TestBumpsOrder(t *testing.T) {
+	collect := func(start, end uint8) []uint8 {
+		var out []uint8
+		for b := range newConfig([]Option{WithBumpRange(start, end)}).bumps() {
+			out = append(out, b)
+		}
+		return out
+	}
+
+	if got := collect(3, 1); len(got) != 3 || got[0] != 3 || got[2] != 1 {
+		t.Errorf("descending range: got %v", got)
+	}
+	if got := collect(254, 255); len(got) != 2 || got[0] != 254 || got[1] != 255 {
+		t.Errorf("ascending range: got %v", got)
+	}
+	if got := collect(7, 7); len(got) != 1 || got[0] != 7 {
+		t.Errorf("single bump: got %v", got)
+	}
+	if got := collect(255, 0); len(got) != 256 {
+		t.Errorf("full range: expected 256 bumps, got %d", len(got))
+	}
+}
+
+func TestWithAscendingBumps(t *testing.T) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("11111111111111111111111111111111"),
+		Seeds:          [][]byte{[]byte("ascending")},
+	}
+
+	valid, err := AllValidBumps(input)
+	if err != nil {
+		t.Fatalf("AllValidBumps failed: %v", err)
+	}
+	lowest := valid[len(valid)-1]
+
+	got, err := GetProgramDerivedAddress(input, WithAscendingBumps())
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if got.Bump != lowest {
+		t.Errorf("expected lowest valid bump %d, got %d", lowest, got.Bump)
+	}
+
+	// Combined with strict mode the search starts at 1, never 0
+	var first uint8
+	for b := range newConfig([]Option{WithStrict(), WithAscendingBumps()}).bumps() {
+		first = b
+		break
+	}
+	if first != 1 {
+		t.Errorf("expected strict ascending search to start at 1, got %d", first)
+	}
+}
+
+func TestWithTraceHook(t *testing.T) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("stats-9")},
+	}
+
+	var traced []uint8
+	var last [32]byte
+	hook := WithTraceHook(func(bump uint8, digest [32]byte, onCurve bool) {
+		traced = append(traced, bump)
+		last = digest
+		if onCurve != isValidPoint(&digest) {
+			t.Errorf("bump %d: onCurve = %v disagrees with the digest", bump, onCurve)
+		}
+	})
+
+	out, stats, err := FindPDAWithStats(input, hook)
+	if err != nil {
+		t.Fatalf("FindPDAWithStats failed: %v", err)
+	}
+
+	if len(traced) != stats.Attempts {
+		t.Errorf("expected %d traced attempts, got %v", stats.Attempts, traced)
+	}
+	if traced[len(traced)-1] != out.Bump || Address(last) != out.Address {
+		t.Errorf("expected the last attempt to be the winning bump %d", out.Bump)
+	}
+
+	traced = nil
+	if _, err := GetProgramDerivedAddress(input, hook); err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if len(traced) != stats.Attempts {
+		t.Errorf("GetProgramDerivedAddress: expected %d traced attempts, got %d", stats.Attempts, len(traced))
+	}
+
+	traced = nil
+	if _, err := AllValidBumps(input, hook); err != nil {
+		t.Fatalf("AllValidBumps failed: %v", err)
+	}
+	if len(traced) != 256 {
+		t.Errorf("AllValidBumps: expected 256 traced attempts, got %d", len(traced))
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("stats-9")},
+	}
+	if _, err := GetProgramDerivedAddress(input, WithLogger(logger)); err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "bump found") || !strings.Contains(buf.String(), "attempts=6") {
+		t.Errorf("expected a bump search event, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	input.Seeds = [][]byte{make([]byte, MaxSeedLength+1)}
+	if _, err := GetProgramDerivedAddress(input, WithLogger(logger)); err == nil {
+		t.Fatal("expected an error for an oversized seed")
+	}
+	if !strings.Contains(buf.String(), "invalid seeds") {
+		t.Errorf("expected a validation event, got: %s", buf.String())
+	}
+}
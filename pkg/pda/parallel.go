@@ -0,0 +1,65 @@
+package pda
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// WithParallelBumps evaluates bump candidates on up to workers goroutines
+// (GOMAXPROCS if workers < 1, limited by SetMaxConcurrency) and still returns the first off-curve bump in
+// search order, i.e. the canonical bump by default. It cuts latency for
+// seeds whose canonical bump is low; for the common case of a bump near 255
+// the sequential search is cheaper. The trace hook, if any, is called
+// concurrently and may see attempts past the winning bump. The search runs
+// on the calling goroutine plus helpers for whatever slots of the cap are
+// free, so it never waits for one, even inside a FindPDABatch worker.
+func WithParallelBumps(workers int) Option {
+	return func(c *config) {
+		c.parallel = workerCount(workers, 0)
+	}
+}
+
+// searchBumpsParallel hands out candidates in search order and tracks the
+// earliest off-curve one; workers skip candidates after it
+func searchBumpsParallel(programID *[32]byte, seeds [][]byte, cfg *config) ([32]byte, uint8, error) {
+	candidates := slices.Collect(cfg.bumps())
+	workers := workerCount(cfg.parallel, len(candidates))
+
+	var next atomic.Int64
+	var best atomic.Int64 // index into candidates of the earliest off-curve bump
+	best.Store(int64(len(candidates)))
+	digests := make([][32]byte, len(candidates))
+
+	// The search may run inside a batch worker that holds a slot of the
+	// cap, so it must not wait for another one
+	runHelpers(workers, func(int) {
+		bh := newBumpHasher(programID, seeds, cfg.marker)
+		defer bh.release()
+
+		for {
+			i := next.Add(1) - 1
+			if i >= best.Load() {
+				return
+			}
+			digest, onCurve := tryBump(bh, candidates[i], cfg)
+			if onCurve {
+				continue
+			}
+			digests[i] = digest
+			for {
+				cur := best.Load()
+				if i >= cur || best.CompareAndSwap(cur, i) {
+					break
+				}
+			}
+		}
+	})
+
+	i := best.Load()
+	if i == int64(len(candidates)) {
+		cfg.debug("pda: no viable bump", "attempts", len(candidates))
+		return [32]byte{}, 0, ErrNoViableBump{}
+	}
+	cfg.debug("pda: bump found", "bump", candidates[i], "workers", workers)
+	return digests[i], candidates[i], nil
+}
@@ -0,0 +1,47 @@
+package pda
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithParallelBumps_MatchesSequential(t *testing.T) {
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	for i := 0; i < 64; i++ {
+		input := ProgramDerivedAddressInput{
+			ProgramAddress: program,
+			Seeds:          [][]byte{[]byte(fmt.Sprintf("stats-%d", i))},
+		}
+
+		for _, opts := range [][]Option{nil, {WithAscendingBumps()}, {WithStrict()}} {
+			want, err := GetProgramDerivedAddress(input, opts...)
+			if err != nil {
+				t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+			}
+			got, err := GetProgramDerivedAddress(input, append(opts, WithParallelBumps(4))...)
+			if err != nil {
+				t.Fatalf("parallel GetProgramDerivedAddress failed: %v", err)
+			}
+			if got != want {
+				t.Errorf("%q: parallel got %+v, want %+v", input.Seeds[0], got, want)
+			}
+		}
+	}
+}
+
+func TestWithParallelBumps_NoViableBump(t *testing.T) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("stats-9")},
+	}
+
+	// Canonical bump is 250, so 255..251 are all on-curve
+	_, err := GetProgramDerivedAddress(input, WithBumpRange(255, 251), WithParallelBumps(0))
+
+	var noBumpErr ErrNoViableBump
+	if !errors.As(err, &noBumpErr) {
+		t.Errorf("expected ErrNoViableBump, got: %v", err)
+	}
+}
@@ -0,0 +1,424 @@
+// Package pda derives Solana Program Derived Addresses (PDAs).
+//
+// It is pure Go with no dependency on syscall/js, so it can be used from
+// servers, tests and the WASM bridge in cmd/wasm alike.
+package pda
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"errors"
+	"fmt"
+	"hash"
+	"sync"
+)
+
+const MaxSeeds = 16
+const MaxSeedLength = 32
+
+var pdaMarkerBytes = []byte("ProgramDerivedAddress")
+
+var (
+	ErrPointOnCurve  = errors.New("hash landed on curve")
+	ErrInvalidBase58 = errors.New("invalid base58 encoding")
+)
+
+// Custom error types for better error handling
+type ErrMaxSeedsExceeded struct {
+	Count int
+	Max   int
+}
+
+func (e ErrMaxSeedsExceeded) Error() string {
+	return fmt.Sprintf("max seeds exceeded: %d (max: %d)", e.Count, e.Max)
+}
+
+func (e ErrMaxSeedsExceeded) Code() ErrorCode {
+	return CodeMaxSeeds
+}
+
+type ErrSeedTooLong struct {
+	Index  int
+	Length int
+	Max    int
+}
+
+func (e ErrSeedTooLong) Error() string {
+	return fmt.Sprintf("seed %d too long: %d bytes (max: %d)", e.Index, e.Length, e.Max)
+}
+
+func (e ErrSeedTooLong) Code() ErrorCode {
+	return CodeSeedTooLong
+}
+
+// ErrNoViableBump is returned when every bump in the searched range lands
+// on the curve
+type ErrNoViableBump struct{}
+
+func (e ErrNoViableBump) Error() string {
+	return "no viable bump found"
+}
+
+func (e ErrNoViableBump) Code() ErrorCode {
+	return CodeNoViableBump
+}
+
+// ErrInvalidAddressLength is returned when decoded address bytes are not
+// exactly 32 bytes long
+type ErrInvalidAddressLength struct {
+	Length int
+}
+
+func (e ErrInvalidAddressLength) Error() string {
+	return fmt.Sprintf("invalid length: %d", e.Length)
+}
+
+func (e ErrInvalidAddressLength) Code() ErrorCode {
+	return CodeBadLength
+}
+
+// Address represents a Solana address. It holds the raw 32 bytes and is
+// only base58-encoded on demand by String, so derivations that return
+// Address values (everything except FindPDA) never pay for encoding, and
+// results can be compared with ==.
+type Address [32]byte
+
+// ZeroAddress is the all-zero key ("11111111111111111111111111111111"). It
+// is both the System Program ID and the default pubkey Solana accounts use
+// for "unset" fields, so it is a valid Address everywhere in this package.
+var ZeroAddress Address
+
+// NewAddress creates a new Address from a base58 string, validating it
+func NewAddress(addr string) (Address, error) {
+	b, err := DecodeAddress(addr)
+	if err != nil {
+		return Address{}, err
+	}
+	return Address(b), nil
+}
+
+// NewAddressFromBytes creates an Address from raw bytes, which must be
+// exactly 32 bytes long
+func NewAddressFromBytes(b []byte) (Address, error) {
+	if len(b) != 32 {
+		return Address{}, ErrInvalidAddressLength{Length: len(b)}
+	}
+	return Address(b), nil
+}
+
+// MustNewAddress is like NewAddress but panics on invalid input. It is
+// intended for package-level constants and tests.
+func MustNewAddress(addr string) Address {
+	a, err := NewAddress(addr)
+	if err != nil {
+		panic(fmt.Sprintf("pda: invalid address %q: %v", addr, err))
+	}
+	return a
+}
+
+// String returns the base58 encoding of the Address
+func (a Address) String() string {
+	return AddressFromBytes(a)
+}
+
+// ToBytes returns the 32-byte representation of the Address. The error is
+// always nil and is kept for compatibility with the string-backed Address.
+func (a Address) ToBytes() ([32]byte, error) {
+	return a, nil
+}
+
+// IsZero reports whether the Address is the all-zero key
+func (a Address) IsZero() bool {
+	return a == ZeroAddress
+}
+
+// IsOnCurve reports whether the Address is a valid ed25519 point, i.e. a
+// regular keypair address rather than a PDA-like off-curve address. The
+// error is always nil, as with ToBytes.
+func (a Address) IsOnCurve() (bool, error) {
+	b := [32]byte(a)
+	return isValidPoint(&b), nil
+}
+
+// ProgramDerivedAddressInput contains the inputs for PDA generation
+type ProgramDerivedAddressInput struct {
+	ProgramAddress Address
+	Seeds          [][]byte
+}
+
+// ProgramDerivedAddressOutput contains the result of PDA generation
+type ProgramDerivedAddressOutput struct {
+	Address Address `json:"address"`
+	Bump    uint8   `json:"bump"`
+}
+
+// --- Address Logic ---
+
+func AddressFromBytes(bytes [32]byte) string {
+	var buf [maxAddressLen]byte
+	return string(AppendAddress(buf[:0], bytes))
+}
+
+func DecodeAddress(addr string) ([32]byte, error) {
+	var arr [32]byte
+	if err := decodeAddress(&arr, addr); err != nil {
+		return [32]byte{}, err
+	}
+	return arr, nil
+}
+
+// --- PDA Logic ---
+
+// validateSeeds checks the seed count and per-seed lengths. reserved is the
+// number of extra seeds (e.g. the bump) that will be appended later.
+func validateSeeds(seeds [][]byte, reserved int, cfg *config) error {
+	err := checkSeeds(seeds, reserved, cfg)
+	if err != nil {
+		cfg.debug("pda: invalid seeds", "seeds", len(seeds), "error", err)
+	}
+	return err
+}
+
+func checkSeeds(seeds [][]byte, reserved int, cfg *config) error {
+	if len(seeds)+reserved > cfg.maxSeeds {
+		if cfg.strict {
+			return strictSeedError(reserved)
+		}
+		return ErrMaxSeedsExceeded{Count: len(seeds) + reserved, Max: cfg.maxSeeds}
+	}
+
+	for i, seed := range seeds {
+		if len(seed) > cfg.maxSeedLength {
+			if cfg.strict {
+				return strictSeedError(reserved)
+			}
+			return ErrSeedTooLong{Index: i, Length: len(seed), Max: cfg.maxSeedLength}
+		}
+	}
+
+	return nil
+}
+
+// hashPDA computes sha256(seeds || bump || programID || marker).
+// bump may be nil when the caller already included it in seeds.
+func hashPDA(seeds [][]byte, bump []byte, programID *[32]byte, marker []byte) [32]byte {
+	bh := bumpHasherPool.Get().(*bumpHasher)
+	defer bumpHasherPool.Put(bh)
+
+	hasher := bh.h
+	hasher.Reset()
+
+	// 1. Write all user-provided seeds
+	for _, seed := range seeds {
+		hasher.Write(seed)
+	}
+
+	// 2. Write the bump seed
+	hasher.Write(bump)
+
+	// 3. Write Program ID
+	hasher.Write(programID[:])
+
+	// 4. Write Marker
+	hasher.Write(marker)
+
+	return [32]byte(hasher.Sum(bh.buf[:0]))
+}
+
+// bumpHasher hashes the seeds once and replays the saved sha256 midstate
+// for each bump, so a bump search does not rehash every seed per attempt.
+// It is not safe for concurrent use. Hashers are pooled: get one with
+// newBumpHasher and hand it back with release.
+type bumpHasher struct {
+	h         hash.Hash
+	midstate  []byte
+	programID *[32]byte
+	marker    []byte
+	buf       [sha256.Size]byte // scratch for the bump byte and the sum
+
+	// The multi-buffer path's view of the midstate, set by prepareLanes
+	state  [8]uint32
+	tail   []byte
+	bumpAt int
+}
+
+var bumpHasherPool = sync.Pool{
+	New: func() any { return &bumpHasher{h: sha256.New()} },
+}
+
+func newBumpHasher(programID *[32]byte, seeds [][]byte, marker []byte) *bumpHasher {
+	b := bumpHasherPool.Get().(*bumpHasher)
+	b.h.Reset()
+	for _, seed := range seeds {
+		b.h.Write(seed)
+	}
+	// sha256's digest always implements BinaryAppender and never fails
+	b.midstate, _ = b.h.(encoding.BinaryAppender).AppendBinary(b.midstate[:0])
+	b.programID = programID
+	b.marker = marker
+	return b
+}
+
+// release returns b to the pool; b must not be used afterwards
+func (b *bumpHasher) release() {
+	b.programID = nil
+	b.marker = nil
+	bumpHasherPool.Put(b)
+}
+
+// sum returns sha256(seeds || bump || programID || marker)
+func (b *bumpHasher) sum(bump uint8) [32]byte {
+	b.h.(encoding.BinaryUnmarshaler).UnmarshalBinary(b.midstate)
+	b.buf[0] = bump
+	b.h.Write(b.buf[:1])
+	b.h.Write(b.programID[:])
+	b.h.Write(b.marker)
+
+	return [32]byte(b.h.Sum(b.buf[:0]))
+}
+
+// tryBump hashes the seeds with bump appended, reports whether the result
+// lands on the curve and notifies the trace hook, if any
+func tryBump(bh *bumpHasher, bump uint8, cfg *config) ([32]byte, bool) {
+	digest := bh.sum(bump)
+	return digest, checkBump(bump, &digest, cfg)
+}
+
+// checkBump reports whether digest, the hash for bump, lands on the curve
+// and notifies the trace hook, if any
+func checkBump(bump uint8, digest *[32]byte, cfg *config) bool {
+	onCurve := isValidPoint(digest)
+	if cfg.trace != nil {
+		cfg.trace(bump, *digest, onCurve)
+	}
+	return onCurve
+}
+
+// findProgramAddress searches the configured bump range (255 down to 0 by
+// default) for an off-curve address, consulting the cache first if one is
+// configured
+func findProgramAddress(programID *[32]byte, seeds [][]byte, cfg *config) ([32]byte, uint8, error) {
+	if cfg.cache == nil {
+		return searchBumps(programID, seeds, cfg)
+	}
+
+	key := cacheKey(programID, seeds, cfg)
+	if out, ok := cfg.cache.Get(key); ok {
+		cfg.debug("pda: cache hit", "bump", out.Bump)
+		return out.Address, out.Bump, nil
+	}
+
+	digest, bump, err := searchBumps(programID, seeds, cfg)
+	if err == nil {
+		cfg.cache.Put(key, ProgramDerivedAddressOutput{Address: Address(digest), Bump: bump})
+	}
+	return digest, bump, err
+}
+
+// searchBumps walks the bump range and returns the first off-curve address
+func searchBumps(programID *[32]byte, seeds [][]byte, cfg *config) ([32]byte, uint8, error) {
+	if cfg.parallel > 1 {
+		return searchBumpsParallel(programID, seeds, cfg)
+	}
+
+	bh := newBumpHasher(programID, seeds, cfg.marker)
+	defer bh.release()
+	return searchBumpsWith(bh, cfg)
+}
+
+// searchBumpsWith is the sequential bump search over a prepared hasher
+func searchBumpsWith(bh *bumpHasher, cfg *config) ([32]byte, uint8, error) {
+	attempts := 0
+	for bump, digest := range bh.digests(cfg) {
+		attempts++
+
+		// Check if point is on curve (invalid for PDA)
+		if checkBump(bump, &digest, cfg) {
+			continue // It IS on the curve, invalid PDA, try next bump
+		}
+
+		// Valid PDA found
+		cfg.debug("pda: bump found", "bump", bump, "attempts", attempts)
+		return digest, bump, nil
+	}
+
+	cfg.debug("pda: no viable bump", "attempts", attempts)
+	return [32]byte{}, 0, ErrNoViableBump{}
+}
+
+// createProgramAddress hashes the seeds as-is and rejects on-curve results
+func createProgramAddress(programID *[32]byte, seeds [][]byte, cfg *config) ([32]byte, error) {
+	digest := hashPDA(seeds, nil, programID, cfg.marker)
+	if isValidPoint(&digest) {
+		return [32]byte{}, ErrPointOnCurve
+	}
+	return digest, nil
+}
+
+// GetProgramDerivedAddress finds a valid PDA and bump seed
+func GetProgramDerivedAddress(input ProgramDerivedAddressInput, opts ...Option) (ProgramDerivedAddressOutput, error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(input.Seeds, 1, cfg); err != nil {
+		return ProgramDerivedAddressOutput{}, err
+	}
+
+	return deriveValidated(input, cfg)
+}
+
+// deriveValidated runs the bump search for input, whose seeds have already
+// passed validateSeeds
+func deriveValidated(input ProgramDerivedAddressInput, cfg *config) (ProgramDerivedAddressOutput, error) {
+	programIdBytes := [32]byte(input.ProgramAddress)
+
+	digest, bump, err := findProgramAddress(&programIdBytes, input.Seeds, cfg)
+	if err != nil {
+		return ProgramDerivedAddressOutput{}, err
+	}
+
+	return ProgramDerivedAddressOutput{
+		Address: Address(digest),
+		Bump:    bump,
+	}, nil
+}
+
+// CreateProgramDerivedAddress creates a PDA with the provided seeds (including bump)
+// This does NOT search for a valid bump - it uses the seeds as-is
+func CreateProgramDerivedAddress(input ProgramDerivedAddressInput, opts ...Option) (Address, error) {
+	cfg := newConfig(opts)
+
+	if err := validateSeeds(input.Seeds, 0, cfg); err != nil {
+		return Address{}, err
+	}
+
+	programIdBytes := [32]byte(input.ProgramAddress)
+
+	digest, err := createProgramAddress(&programIdBytes, input.Seeds, cfg)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return Address(digest), nil
+}
+
+func FindPDA(programIdStr string, seeds [][]byte, opts ...Option) (string, uint8, error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(seeds, 1, cfg); err != nil {
+		return "", 0, err
+	}
+
+	programIdBytes, err := decodeProgramID(programIdStr)
+	if err != nil {
+		return "", 0, err
+	}
+
+	digest, bump, err := findProgramAddress(&programIdBytes, seeds, cfg)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return AddressFromBytes(digest), bump, nil
+}
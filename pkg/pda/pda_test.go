@@ -1,4 +1,4 @@
-package main
+package pda
 
 import (
 	"errors"
@@ -23,7 +23,7 @@ func TestGetProgramDerivedAddress_Success(t *testing.T) {
 	}
 
 	// Verify that a PDA was returned
-	if pda.Address == "" {
+	if pda.Address.IsZero() {
 		t.Error("expected non-empty address")
 	}
 
@@ -91,7 +91,7 @@ func TestGetProgramDerivedAddress_MultipleSeeds(t *testing.T) {
 		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
 	}
 
-	if pda.Address == "" {
+	if pda.Address.IsZero() {
 		t.Error("expected non-empty address")
 	}
 }
@@ -113,7 +113,7 @@ func TestGetProgramDerivedAddress_EmptySeeds(t *testing.T) {
 		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
 	}
 
-	if pda.Address == "" {
+	if pda.Address.IsZero() {
 		t.Error("expected non-empty address")
 	}
 }
@@ -141,7 +141,7 @@ func TestGetProgramDerivedAddress_MaxSeeds(t *testing.T) {
 		t.Fatalf("GetProgramDerivedAddress failed with max seeds: %v", err)
 	}
 
-	if pda.Address == "" {
+	if pda.Address.IsZero() {
 		t.Error("expected non-empty address")
 	}
 }
@@ -201,14 +201,49 @@ func TestGetProgramDerivedAddress_SeedTooLong(t *testing.T) {
 	}
 }
 
-func TestGetProgramDerivedAddress_InvalidProgramAddress(t *testing.T) {
-	// Test with an invalid program address
+func TestGetProgramDerivedAddress_SeedTooLongIndex(t *testing.T) {
 	input := ProgramDerivedAddressInput{
-		ProgramAddress: Address("invalid-base58-!@#$"),
-		Seeds:          [][]byte{[]byte("test")},
+		ProgramAddress: ZeroAddress,
+		Seeds:          [][]byte{[]byte("ok"), []byte("ok"), make([]byte, MaxSeedLength+5)},
 	}
 
 	_, err := GetProgramDerivedAddress(input)
+
+	var seedTooLongErr ErrSeedTooLong
+	if !errors.As(err, &seedTooLongErr) {
+		t.Fatalf("expected ErrSeedTooLong, got: %v", err)
+	}
+	if seedTooLongErr.Index != 2 || seedTooLongErr.Length != MaxSeedLength+5 {
+		t.Errorf("unexpected error context: %+v", seedTooLongErr)
+	}
+}
+
+func TestGetProgramDerivedAddress_NoViableBump(t *testing.T) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: ZeroAddress,
+		Seeds:          [][]byte{[]byte("no-bump")},
+	}
+
+	canonical, err := GetProgramDerivedAddress(input)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if canonical.Bump == 255 {
+		t.Skip("canonical bump is 255, no on-curve bump above it")
+	}
+
+	// Bump 255 is on the curve, so a range containing only it has no result
+	_, err = GetProgramDerivedAddress(input, WithBumpRange(255, 255))
+
+	var noBumpErr ErrNoViableBump
+	if !errors.As(err, &noBumpErr) {
+		t.Errorf("expected ErrNoViableBump, got: %v", err)
+	}
+}
+
+func TestGetProgramDerivedAddress_InvalidProgramAddress(t *testing.T) {
+	// Invalid program addresses are rejected when the Address is constructed
+	_, err := NewAddress("invalid-base58-!@#$")
 	if err == nil {
 		t.Fatal("expected error for invalid program address")
 	}
@@ -287,3 +322,151 @@ func TestGetProgramDerivedAddress_VerifyBumpSeedWorks(t *testing.T) {
 		t.Errorf("addresses don't match: %s != %s", verifyAddr, pda.Address)
 	}
 }
+
+func TestNewAddressFromBytes(t *testing.T) {
+	want := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+
+	got, err := NewAddressFromBytes(want[:])
+	if err != nil {
+		t.Fatalf("NewAddressFromBytes failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if _, err := NewAddressFromBytes(make([]byte, 31)); err == nil {
+		t.Error("expected error for 31-byte input")
+	}
+}
+
+func TestMustNewAddress_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustNewAddress to panic on invalid input")
+		}
+	}()
+	MustNewAddress("invalid-base58-!@#$")
+}
+
+func TestAddress_IsOnCurve(t *testing.T) {
+	// Program IDs are regular ed25519 public keys
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	onCurve, err := program.IsOnCurve()
+	if err != nil {
+		t.Fatalf("IsOnCurve failed: %v", err)
+	}
+	if !onCurve {
+		t.Error("expected program ID to be on the curve")
+	}
+
+	pda, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: program,
+		Seeds:          [][]byte{[]byte("on-curve")},
+	})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	onCurve, err = pda.Address.IsOnCurve()
+	if err != nil {
+		t.Fatalf("IsOnCurve failed: %v", err)
+	}
+	if onCurve {
+		t.Error("expected PDA to be off the curve")
+	}
+}
+
+func TestZeroAddress(t *testing.T) {
+	addr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to decode the zero key: %v", err)
+	}
+	if !addr.IsZero() || addr != ZeroAddress {
+		t.Errorf("expected zero address, got %s", addr)
+	}
+	if ZeroAddress.String() != "11111111111111111111111111111111" {
+		t.Errorf("unexpected zero address encoding: %s", ZeroAddress)
+	}
+
+	// The zero key is a valid program and seed everywhere
+	pda, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: ZeroAddress,
+		Seeds:          [][]byte{ZeroAddress[:]},
+	})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if pda.Address.IsZero() {
+		t.Error("expected non-zero PDA")
+	}
+
+	addrStr, bump, err := FindPDA(ZeroAddress.String(), [][]byte{ZeroAddress[:]})
+	if err != nil {
+		t.Fatalf("FindPDA failed: %v", err)
+	}
+	if addrStr != pda.Address.String() || bump != pda.Bump {
+		t.Errorf("FindPDA = (%s, %d), want (%s, %d)", addrStr, bump, pda.Address, pda.Bump)
+	}
+}
+
+func TestBumpHasher_MatchesHashPDA(t *testing.T) {
+	programID := [32]byte(MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"))
+	seeds := [][]byte{[]byte("midstate"), make([]byte, 32), {1, 2, 3}}
+
+	bh := newBumpHasher(&programID, seeds, pdaMarkerBytes)
+	for bump := 255; bump >= 0; bump-- {
+		want := hashPDA(seeds, []byte{uint8(bump)}, &programID, pdaMarkerBytes)
+		if got := bh.sum(uint8(bump)); got != want {
+			t.Fatalf("bump %d: midstate hash %x, want %x", bump, got, want)
+		}
+	}
+}
+
+func BenchmarkAllValidBumps_LongSeeds(b *testing.B) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          make([][]byte, MaxSeeds-1),
+	}
+	for i := range input.Seeds {
+		input.Seeds[i] = make([]byte, MaxSeedLength)
+	}
+	for b.Loop() {
+		if _, err := AllValidBumps(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetProgramDerivedAddress(b *testing.B) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("vault"), make([]byte, 32)},
+	}
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := GetProgramDerivedAddress(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGetProgramDerivedAddress_DoesNotEncode(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not stable under the race detector")
+	}
+
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("vault")},
+	}
+
+	// Only the options and the bump iterator are allocated; base58 encoding
+	// the result would add a string and the encoder's scratch buffer
+	allocs := testing.AllocsPerRun(100, func() {
+		GetProgramDerivedAddress(input)
+	})
+	if allocs > 2 {
+		t.Errorf("expected at most 2 allocations, got %v", allocs)
+	}
+}
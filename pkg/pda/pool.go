@@ -0,0 +1,120 @@
+package pda
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// concurrency holds the process-wide cap on worker goroutines. sem is nil
+// when there is no cap.
+var concurrency struct {
+	mu  sync.Mutex
+	max int
+	sem chan struct{}
+}
+
+// SetMaxConcurrency caps the number of worker goroutines this package runs
+// at once across all calls (batch derivation, grinding, parallel bump
+// search), so an embedding service keeps CPU for its own goroutines. Calls
+// that ask for more workers are trimmed to n, and concurrent calls wait for
+// a free slot. n < 1 removes the cap (the default). Calls already running
+// keep the limit they started with.
+func SetMaxConcurrency(n int) {
+	concurrency.mu.Lock()
+	defer concurrency.mu.Unlock()
+
+	if n < 1 {
+		concurrency.max, concurrency.sem = 0, nil
+		return
+	}
+	concurrency.max, concurrency.sem = n, make(chan struct{}, n)
+}
+
+// MaxConcurrency returns the cap set by SetMaxConcurrency, or 0 if none
+func MaxConcurrency() int {
+	concurrency.mu.Lock()
+	defer concurrency.mu.Unlock()
+	return concurrency.max
+}
+
+// workerCount resolves a requested worker count: GOMAXPROCS if requested
+// < 1, then trimmed to the global cap and, if jobs > 0, to the number of
+// jobs
+func workerCount(requested, jobs int) int {
+	n := requested
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	if limit := MaxConcurrency(); limit > 0 && n > limit {
+		n = limit
+	}
+	if jobs > 0 && n > jobs {
+		n = jobs
+	}
+	return n
+}
+
+// runWorkers runs work on n goroutines, passing each its index in [0, n),
+// and waits for them to finish. Each
+// goroutine holds a slot of the global cap while it runs; goroutines still
+// waiting for a slot when ctx is done skip their work.
+func runWorkers(ctx context.Context, n int, work func(worker int)) {
+	concurrency.mu.Lock()
+	sem := concurrency.sem
+	concurrency.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					return
+				}
+			}
+			work(w)
+		}(w)
+	}
+	wg.Wait()
+}
+
+// runHelpers runs work on the calling goroutine and on up to n-1 helper
+// goroutines, passing each its index in [0, n), and waits for them to
+// finish. Unlike runWorkers it never waits for a slot of the global cap: a
+// helper only starts if a slot is free right away, so work must spread its
+// jobs over however many workers actually run. Use it for pools that can
+// run inside another pool's worker (parallel bump search within a batch),
+// where the outer workers may hold every slot.
+func runHelpers(n int, work func(worker int)) {
+	concurrency.mu.Lock()
+	sem := concurrency.sem
+	concurrency.mu.Unlock()
+
+	var wg sync.WaitGroup
+helpers:
+	for w := 1; w < n; w++ {
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			default:
+				// The cap is in use; the workers already running take the rest
+				break helpers
+			}
+		}
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+			work(w)
+		}(w)
+	}
+	work(0)
+	wg.Wait()
+}
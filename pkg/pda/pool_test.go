@@ -0,0 +1,141 @@
+package pda
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerCount(t *testing.T) {
+	defer SetMaxConcurrency(0)
+
+	if got := workerCount(0, 0); got != runtime.GOMAXPROCS(0) {
+		t.Errorf("expected GOMAXPROCS by default, got %d", got)
+	}
+	if got := workerCount(8, 3); got != 3 {
+		t.Errorf("expected workers trimmed to jobs, got %d", got)
+	}
+
+	SetMaxConcurrency(2)
+	if got := workerCount(8, 0); got != 2 {
+		t.Errorf("expected workers trimmed to the cap, got %d", got)
+	}
+	if MaxConcurrency() != 2 {
+		t.Errorf("expected MaxConcurrency 2, got %d", MaxConcurrency())
+	}
+
+	SetMaxConcurrency(0)
+	if got := workerCount(8, 0); got != 8 {
+		t.Errorf("expected the cap to be removed, got %d", got)
+	}
+}
+
+func TestRunWorkers_RespectsCap(t *testing.T) {
+	defer SetMaxConcurrency(0)
+	SetMaxConcurrency(2)
+
+	var active, peak atomic.Int32
+	work := func(int) {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		runtime.Gosched()
+		active.Add(-1)
+	}
+
+	// Two concurrent calls share the cap
+	done := make(chan struct{})
+	go func() {
+		runWorkers(context.Background(), 4, work)
+		close(done)
+	}()
+	runWorkers(context.Background(), 4, work)
+	<-done
+
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent workers, saw %d", peak.Load())
+	}
+}
+
+func TestRunWorkers_CancelledWhileWaiting(t *testing.T) {
+	defer SetMaxConcurrency(0)
+	SetMaxConcurrency(1)
+
+	// Hold the only slot
+	release := make(chan struct{})
+	held := make(chan struct{})
+	go runWorkers(context.Background(), 1, func(int) {
+		close(held)
+		<-release
+	})
+	<-held
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ran := false
+	runWorkers(ctx, 1, func(int) { ran = true })
+	close(release)
+
+	if ran {
+		t.Error("expected a worker waiting for a slot to skip its work after cancellation")
+	}
+}
+
+func TestRunHelpers_CapInUse(t *testing.T) {
+	defer SetMaxConcurrency(0)
+	SetMaxConcurrency(1)
+
+	// Hold the only slot
+	release := make(chan struct{})
+	held := make(chan struct{})
+	go runWorkers(context.Background(), 1, func(int) {
+		close(held)
+		<-release
+	})
+	<-held
+	defer close(release)
+
+	var ran atomic.Int32
+	runHelpers(4, func(int) { ran.Add(1) })
+	if ran.Load() != 1 {
+		t.Errorf("expected only the calling goroutine to run, got %d workers", ran.Load())
+	}
+}
+
+// Bump search inside batch workers must not wait for slots the batch holds
+func TestFindPDABatch_NestedParallelBumps(t *testing.T) {
+	defer SetMaxConcurrency(0)
+	SetMaxConcurrency(2)
+
+	inputs := make([]ProgramDerivedAddressInput, 8)
+	for i := range inputs {
+		inputs[i] = ProgramDerivedAddressInput{
+			ProgramAddress: MustNewAddress("11111111111111111111111111111111"),
+			Seeds:          [][]byte{{byte(i)}},
+		}
+	}
+
+	done := make(chan []error)
+	go func() {
+		_, errs := FindPDABatch(inputs, WithWorkers(2), WithDeriveOptions(WithParallelBumps(2)))
+		done <- errs
+	}()
+
+	select {
+	case errs := <-done:
+		for i, err := range errs {
+			if err != nil {
+				t.Errorf("input %d: %v", i, err)
+			}
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("FindPDABatch with nested parallel bump search deadlocked")
+	}
+}
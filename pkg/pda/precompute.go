@@ -0,0 +1,66 @@
+package pda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Precompute derives every input and stores the results in the cache set
+// with WithCache, so the first real lookup of a known PDA is a cache hit
+// instead of a bump search. Work is spread over GOMAXPROCS goroutines
+// (limited by SetMaxConcurrency). Inputs already cached are skipped.
+//
+// Precompute stops early when ctx is done. Invalid inputs do not stop the
+// others; their errors are joined, tagged with the input index, into the
+// returned error together with any ctx error.
+func Precompute(ctx context.Context, inputs []ProgramDerivedAddressInput, opts ...Option) error {
+	cfg := newConfig(opts)
+	if cfg.cache == nil {
+		return errors.New("precompute needs a cache (WithCache)")
+	}
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		next atomic.Int64
+	)
+	fail := func(i int, err error) {
+		mu.Lock()
+		errs = append(errs, fmt.Errorf("input %d: %w", i, err))
+		mu.Unlock()
+	}
+
+	runWorkers(ctx, workerCount(0, len(inputs)), func(int) {
+		for ctx.Err() == nil {
+			i := int(next.Add(1) - 1)
+			if i >= len(inputs) {
+				return
+			}
+			// Validate seeds (need room for bump seed)
+			if err := validateSeeds(inputs[i].Seeds, 1, cfg); err != nil {
+				fail(i, err)
+				continue
+			}
+			if _, err := deriveValidated(inputs[i], cfg); err != nil {
+				fail(i, err)
+			}
+		}
+	})
+
+	return errors.Join(append(errs, ctx.Err())...)
+}
+
+// PrecomputeAsync runs Precompute in the background, so startup can go on
+// while the cache warms. The returned channel receives Precompute's result
+// and is then closed.
+func PrecomputeAsync(ctx context.Context, inputs []ProgramDerivedAddressInput, opts ...Option) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		done <- Precompute(ctx, inputs, opts...)
+	}()
+	return done
+}
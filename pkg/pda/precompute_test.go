@@ -0,0 +1,69 @@
+package pda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func precomputeInputs(n int) []ProgramDerivedAddressInput {
+	inputs := make([]ProgramDerivedAddressInput, n)
+	for i := range inputs {
+		inputs[i] = ProgramDerivedAddressInput{
+			ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+			Seeds:          [][]byte{[]byte(fmt.Sprintf("warm-%d", i))},
+		}
+	}
+	return inputs
+}
+
+func TestPrecompute(t *testing.T) {
+	cache := NewLRUCache(64)
+	inputs := precomputeInputs(20)
+
+	if err := Precompute(context.Background(), inputs, WithCache(cache)); err != nil {
+		t.Fatalf("Precompute failed: %v", err)
+	}
+	if cache.Len() != len(inputs) {
+		t.Fatalf("expected %d cached results, got %d", len(inputs), cache.Len())
+	}
+
+	// Every later lookup is served from the cache
+	for _, input := range inputs {
+		var attempts int
+		hook := WithTraceHook(func(uint8, [32]byte, bool) { attempts++ })
+		if _, err := GetProgramDerivedAddress(input, WithCache(cache), hook); err != nil {
+			t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+		}
+		if attempts != 0 {
+			t.Errorf("expected a cache hit, got %d bump attempts", attempts)
+		}
+	}
+}
+
+func TestPrecompute_Errors(t *testing.T) {
+	if err := Precompute(context.Background(), precomputeInputs(1)); err == nil {
+		t.Error("expected an error without a cache")
+	}
+
+	inputs := precomputeInputs(3)
+	inputs[1].Seeds = [][]byte{make([]byte, MaxSeedLength+1)}
+	cache := NewLRUCache(8)
+
+	err := <-PrecomputeAsync(context.Background(), inputs, WithCache(cache))
+	var seedTooLongErr ErrSeedTooLong
+	if !errors.As(err, &seedTooLongErr) || !strings.Contains(err.Error(), "input 1") {
+		t.Errorf("expected ErrSeedTooLong for input 1, got: %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected the valid inputs to be cached, got %d", cache.Len())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Precompute(ctx, precomputeInputs(3), WithCache(NewLRUCache(8))); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
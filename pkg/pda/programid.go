@@ -0,0 +1,38 @@
+package pda
+
+import "sync"
+
+// maxProgramIDCache bounds the decoded program ID cache. Callers rarely use
+// more than a handful of programs, so the cache is simply cleared when full
+// rather than tracking recency.
+const maxProgramIDCache = 256
+
+var programIDCache = struct {
+	sync.RWMutex
+	m map[string][32]byte
+}{m: make(map[string][32]byte)}
+
+// decodeProgramID is DecodeAddress memoized for the program ID strings
+// FindPDA sees repeatedly. Only valid IDs are cached.
+func decodeProgramID(s string) ([32]byte, error) {
+	programIDCache.RLock()
+	b, ok := programIDCache.m[s]
+	programIDCache.RUnlock()
+	if ok {
+		return b, nil
+	}
+
+	b, err := DecodeAddress(s)
+	if err != nil {
+		return b, err
+	}
+
+	programIDCache.Lock()
+	if len(programIDCache.m) >= maxProgramIDCache {
+		clear(programIDCache.m)
+	}
+	programIDCache.m[s] = b
+	programIDCache.Unlock()
+
+	return b, nil
+}
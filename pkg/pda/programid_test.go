@@ -0,0 +1,49 @@
+package pda
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeProgramID(t *testing.T) {
+	const token = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	want, _ := DecodeAddress(token)
+
+	for i := 0; i < 2; i++ {
+		got, err := decodeProgramID(token)
+		if err != nil {
+			t.Fatalf("decodeProgramID failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("got %x, want %x", got, want)
+		}
+	}
+
+	if _, err := decodeProgramID("not-base58!"); !errors.Is(err, ErrInvalidBase58) {
+		t.Errorf("expected ErrInvalidBase58, got: %v", err)
+	}
+
+	programIDCache.RLock()
+	_, cachedInvalid := programIDCache.m["not-base58!"]
+	programIDCache.RUnlock()
+	if cachedInvalid {
+		t.Error("invalid program IDs must not be cached")
+	}
+}
+
+func TestDecodeProgramID_Bounded(t *testing.T) {
+	for i := 0; i < maxProgramIDCache+10; i++ {
+		var raw [32]byte
+		raw[0], raw[1] = byte(i), byte(i>>8)
+		if _, err := decodeProgramID(AddressFromBytes(raw)); err != nil {
+			t.Fatalf("decodeProgramID failed: %v", err)
+		}
+	}
+
+	programIDCache.RLock()
+	n := len(programIDCache.m)
+	programIDCache.RUnlock()
+	if n > maxProgramIDCache {
+		t.Errorf("cache grew past its bound: %d", n)
+	}
+}
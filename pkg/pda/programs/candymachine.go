@@ -0,0 +1,29 @@
+package programs
+
+import "raccoon-wasm/pkg/pda"
+
+// Candy Machine v3 program IDs
+var (
+	CandyMachineCoreProgramID = pda.MustNewAddress("CndyV3LdqHUfDLmE5naZjVN8rBZz4tqhdefbAnjHG3JR")
+	CandyGuardProgramID       = pda.MustNewAddress("Guard1JwRhJkVH6XZhzoYxeBVQe872VH6QggF4BWmS9g")
+)
+
+// FindCandyMachineAuthorityPDA returns the authority PDA of candyMachine:
+// the PDA of ["candy_machine", candyMachine] under the Candy Machine Core
+// program. It is the update authority and first verified creator of the
+// NFTs the machine mints, and mint instructions take it as an account.
+func FindCandyMachineAuthorityPDA(candyMachine pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+	return pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: CandyMachineCoreProgramID,
+		Seeds:          [][]byte{[]byte("candy_machine"), candyMachine[:]},
+	})
+}
+
+// FindCandyGuardPDA returns the candy guard account created from base: the
+// PDA of ["candy_guard", base] under the Candy Guard program
+func FindCandyGuardPDA(base pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+	return pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: CandyGuardProgramID,
+		Seeds:          [][]byte{[]byte("candy_guard"), base[:]},
+	})
+}
@@ -0,0 +1,33 @@
+package programs
+
+import (
+	"testing"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+func TestCandyMachinePDAs(t *testing.T) {
+	tests := []struct {
+		name    string
+		derive  func(pda.Address) (pda.ProgramDerivedAddressOutput, error)
+		program string
+		prefix  string
+	}{
+		{"FindCandyMachineAuthorityPDA", FindCandyMachineAuthorityPDA, "CndyV3LdqHUfDLmE5naZjVN8rBZz4tqhdefbAnjHG3JR", "candy_machine"},
+		{"FindCandyGuardPDA", FindCandyGuardPDA, "Guard1JwRhJkVH6XZhzoYxeBVQe872VH6QggF4BWmS9g", "candy_guard"},
+	}
+	for _, tt := range tests {
+		got, err := tt.derive(testOwner)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.name, err)
+		}
+
+		addr, bump, err := pda.FindPDA(tt.program, [][]byte{[]byte(tt.prefix), testOwner[:]})
+		if err != nil {
+			t.Fatalf("FindPDA failed: %v", err)
+		}
+		if got.Address.String() != addr || got.Bump != bump {
+			t.Errorf("%s: got %s/%d, want %s/%d", tt.name, got.Address, got.Bump, addr, bump)
+		}
+	}
+}
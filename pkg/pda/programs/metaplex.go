@@ -0,0 +1,53 @@
+package programs
+
+import (
+	"strconv"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// TokenMetadataProgramID is the Metaplex Token Metadata program
+var TokenMetadataProgramID = pda.MustNewAddress("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+
+const (
+	// metadataPrefix is the first seed of every Token Metadata account
+	metadataPrefix = "metadata"
+	// editionSeed follows the mint in edition and edition marker seeds
+	editionSeed = "edition"
+	// EditionMarkerBitSize is the number of editions one edition marker
+	// account records, one bit each
+	EditionMarkerBitSize = 248
+)
+
+// FindMetadataPDA returns the metadata account of mint: the PDA of
+// ["metadata", Token Metadata program, mint] under the Token Metadata
+// program
+func FindMetadataPDA(mint pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+	return pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: TokenMetadataProgramID,
+		Seeds:          [][]byte{[]byte(metadataPrefix), TokenMetadataProgramID[:], mint[:]},
+	})
+}
+
+// FindMasterEditionPDA returns the master edition account of mint: the PDA
+// of ["metadata", Token Metadata program, mint, "edition"]. Print editions
+// use the same derivation for their edition account.
+func FindMasterEditionPDA(mint pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+	return pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: TokenMetadataProgramID,
+		Seeds:          [][]byte{[]byte(metadataPrefix), TokenMetadataProgramID[:], mint[:], []byte(editionSeed)},
+	})
+}
+
+// FindEditionMarkerPDA returns the edition marker account that records
+// whether edition of the master edition mint has been printed. Each marker
+// covers EditionMarkerBitSize editions, so the last seed is edition /
+// EditionMarkerBitSize written as a decimal string, not as an integer:
+// ["metadata", Token Metadata program, mint, "edition", "<edition/248>"].
+func FindEditionMarkerPDA(mint pda.Address, edition uint64) (pda.ProgramDerivedAddressOutput, error) {
+	marker := strconv.FormatUint(edition/EditionMarkerBitSize, 10)
+	return pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: TokenMetadataProgramID,
+		Seeds:          [][]byte{[]byte(metadataPrefix), TokenMetadataProgramID[:], mint[:], []byte(editionSeed), []byte(marker)},
+	})
+}
@@ -0,0 +1,98 @@
+package programs
+
+import (
+	"testing"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+func TestFindMetadataPDA(t *testing.T) {
+	got, err := FindMetadataPDA(testMint)
+	if err != nil {
+		t.Fatalf("FindMetadataPDA failed: %v", err)
+	}
+
+	// The seed layout spelled out: "metadata", metadata program, mint
+	program := pda.MustNewAddress("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+	addr, bump, err := pda.FindPDA(program.String(), [][]byte{[]byte("metadata"), program[:], testMint[:]})
+	if err != nil {
+		t.Fatalf("FindPDA failed: %v", err)
+	}
+	if got.Address.String() != addr || got.Bump != bump {
+		t.Errorf("got %s/%d, want %s/%d", got.Address, got.Bump, addr, bump)
+	}
+}
+
+func TestFindMasterEditionPDA(t *testing.T) {
+	got, err := FindMasterEditionPDA(testMint)
+	if err != nil {
+		t.Fatalf("FindMasterEditionPDA failed: %v", err)
+	}
+
+	program := pda.MustNewAddress("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+	addr, bump, err := pda.FindPDA(program.String(), [][]byte{[]byte("metadata"), program[:], testMint[:], []byte("edition")})
+	if err != nil {
+		t.Fatalf("FindPDA failed: %v", err)
+	}
+	if got.Address.String() != addr || got.Bump != bump {
+		t.Errorf("got %s/%d, want %s/%d", got.Address, got.Bump, addr, bump)
+	}
+}
+
+func TestFindEditionMarkerPDA(t *testing.T) {
+	program := pda.MustNewAddress("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+	tests := []struct {
+		edition uint64
+		marker  string
+	}{
+		{0, "0"},
+		{1, "0"},
+		{247, "0"},
+		{248, "1"},
+		{1000, "4"},
+		{1<<64 - 1, "74382032555280450"},
+	}
+	for _, tt := range tests {
+		got, err := FindEditionMarkerPDA(testMint, tt.edition)
+		if err != nil {
+			t.Fatalf("FindEditionMarkerPDA(%d) failed: %v", tt.edition, err)
+		}
+
+		addr, bump, err := pda.FindPDA(program.String(), [][]byte{[]byte("metadata"), program[:], testMint[:], []byte("edition"), []byte(tt.marker)})
+		if err != nil {
+			t.Fatalf("FindPDA failed: %v", err)
+		}
+		if got.Address.String() != addr || got.Bump != bump {
+			t.Errorf("edition %d: got %s/%d, want %s/%d", tt.edition, got.Address, got.Bump, addr, bump)
+		}
+	}
+}
+
+// Known addresses catch a wrong seed order, which re-deriving from a seed
+// list cannot. The metadata account of wrapped SOL is on mainnet; wrapped
+// SOL has no editions, so the edition accounts are pinned to their
+// derivation from the Token Metadata layout, on top of the same prefix.
+func TestMetaplexPDAs_Pinned(t *testing.T) {
+	wrappedSOL := pda.MustNewAddress("So11111111111111111111111111111111111111112")
+
+	tests := []struct {
+		name   string
+		derive func() (pda.ProgramDerivedAddressOutput, error)
+		want   string
+		bump   uint8
+	}{
+		{"FindMetadataPDA", func() (pda.ProgramDerivedAddressOutput, error) { return FindMetadataPDA(wrappedSOL) }, "6dM4TqWyWJsbx7obrdLcviBkTafD5E8av61zfU6jq57X", 255},
+		{"FindMasterEditionPDA", func() (pda.ProgramDerivedAddressOutput, error) { return FindMasterEditionPDA(wrappedSOL) }, "7r1W5yu5i7ev1wPNGsNuRLcdKW1sCy2x4rwyQkdi9ew2", 254},
+		{"FindEditionMarkerPDA(0)", func() (pda.ProgramDerivedAddressOutput, error) { return FindEditionMarkerPDA(wrappedSOL, 0) }, "4Y1mQRDnq8F8vrvtkgte6Pi1LFgRSqVRXtqh2eMsRyBs", 253},
+		{"FindEditionMarkerPDA(1000)", func() (pda.ProgramDerivedAddressOutput, error) { return FindEditionMarkerPDA(wrappedSOL, 1000) }, "7BviNRiXd28MQUGUu4tFNufY522GGPmqYejF6wYuS3vU", 255},
+	}
+	for _, tt := range tests {
+		got, err := tt.derive()
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.name, err)
+		}
+		if got.Address.String() != tt.want || got.Bump != tt.bump {
+			t.Errorf("%s: got %s/%d, want %s/%d", tt.name, got.Address, got.Bump, tt.want, tt.bump)
+		}
+	}
+}
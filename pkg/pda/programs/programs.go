@@ -0,0 +1,41 @@
+// Package programs derives the PDAs of well-known Solana programs, so
+// callers don't have to get each program's seed layout right themselves.
+//
+// It is kept out of package pda, and out of the core WASM module: the
+// bridge exposes these helpers from a secondary module (cmd/wasmprograms)
+// that JavaScript loads only when it needs them.
+package programs
+
+import "raccoon-wasm/pkg/pda"
+
+// Program IDs used by the helpers
+var (
+	TokenProgramID           = pda.MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	Token2022ProgramID       = pda.MustNewAddress("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	AssociatedTokenProgramID = pda.MustNewAddress("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+)
+
+// GetAssociatedTokenAddress returns the associated token account of owner
+// for a mint of the classic Token program. Token-2022 mints have different
+// associated accounts; use GetAssociatedTokenAddress2022, or
+// GetAssociatedTokenAddressWithProgram with the mint account's owner.
+func GetAssociatedTokenAddress(owner, mint pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+	return GetAssociatedTokenAddressWithProgram(owner, mint, TokenProgramID)
+}
+
+// GetAssociatedTokenAddress2022 returns the associated token account of
+// owner for a mint of the Token-2022 program
+func GetAssociatedTokenAddress2022(owner, mint pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+	return GetAssociatedTokenAddressWithProgram(owner, mint, Token2022ProgramID)
+}
+
+// GetAssociatedTokenAddressWithProgram returns the associated token account
+// of owner for a mint of tokenProgram: the PDA of [owner, tokenProgram,
+// mint] under the Associated Token Account program. tokenProgram must be the
+// program that owns the mint account, or the address belongs to no account.
+func GetAssociatedTokenAddressWithProgram(owner, mint, tokenProgram pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+	return pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{
+		ProgramAddress: AssociatedTokenProgramID,
+		Seeds:          [][]byte{owner[:], tokenProgram[:], mint[:]},
+	})
+}
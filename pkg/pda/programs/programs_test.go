@@ -0,0 +1,54 @@
+package programs
+
+import (
+	"testing"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+var (
+	testOwner = pda.MustNewAddress("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+	testMint  = pda.MustNewAddress("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+)
+
+func TestGetAssociatedTokenAddress(t *testing.T) {
+	got, err := GetAssociatedTokenAddress(testOwner, testMint)
+	if err != nil {
+		t.Fatalf("GetAssociatedTokenAddress failed: %v", err)
+	}
+
+	// The seed layout spelled out: owner, token program, mint
+	tokenProgram := pda.MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	addr, bump, err := pda.FindPDA("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL", [][]byte{testOwner[:], tokenProgram[:], testMint[:]})
+	if err != nil {
+		t.Fatalf("FindPDA failed: %v", err)
+	}
+	if got.Address.String() != addr || got.Bump != bump {
+		t.Errorf("got %s/%d, want %s/%d", got.Address, got.Bump, addr, bump)
+	}
+}
+
+func TestGetAssociatedTokenAddress2022(t *testing.T) {
+	got, err := GetAssociatedTokenAddress2022(testOwner, testMint)
+	if err != nil {
+		t.Fatalf("GetAssociatedTokenAddress2022 failed: %v", err)
+	}
+
+	tokenProgram := pda.MustNewAddress("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	want, err := GetAssociatedTokenAddressWithProgram(testOwner, testMint, tokenProgram)
+	if err != nil {
+		t.Fatalf("GetAssociatedTokenAddressWithProgram failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %s/%d, want %s/%d", got.Address, got.Bump, want.Address, want.Bump)
+	}
+
+	// The token program is part of the derivation
+	classic, err := GetAssociatedTokenAddress(testOwner, testMint)
+	if err != nil {
+		t.Fatalf("GetAssociatedTokenAddress failed: %v", err)
+	}
+	if got.Address == classic.Address {
+		t.Error("Token-2022 and Token associated accounts are the same")
+	}
+}
@@ -0,0 +1,58 @@
+package pda
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProgressFunc receives the number of attempts (or items) done so far and
+// the average rate per second
+type ProgressFunc func(done uint64, rate float64)
+
+// progress counts work done by concurrent workers and calls fn at most
+// once per interval, from whichever worker notices the interval has passed.
+// Reporting inline rather than from a ticker goroutine keeps reports coming
+// under js/wasm, where a busy worker is never preempted. fn is never called
+// concurrently.
+type progress struct {
+	fn       ProgressFunc
+	interval time.Duration
+	start    time.Time
+
+	done atomic.Uint64
+	due  atomic.Int64 // nanoseconds since start of the next report
+	mu   sync.Mutex
+}
+
+func newProgress(fn ProgressFunc, interval time.Duration) *progress {
+	p := &progress{fn: fn, interval: interval, start: time.Now()}
+	p.due.Store(int64(interval))
+	return p
+}
+
+// add records n units of work, reporting if an interval has passed
+func (p *progress) add(n uint64) {
+	p.done.Add(n)
+	if p.fn == nil || p.interval <= 0 {
+		return
+	}
+
+	due := p.due.Load()
+	elapsed := int64(time.Since(p.start))
+	if elapsed < due || !p.due.CompareAndSwap(due, elapsed+int64(p.interval)) {
+		return
+	}
+	p.report()
+}
+
+// report calls fn with the current totals
+func (p *progress) report() {
+	if p.fn == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	done := p.done.Load()
+	p.fn(done, float64(done)/time.Since(p.start).Seconds())
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package pda
+
+// raceEnabled skips allocation tests, since the race detector makes
+// sync.Pool drop items at random
+const raceEnabled = true
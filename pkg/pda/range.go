@@ -0,0 +1,58 @@
+package pda
+
+import (
+	"encoding/binary"
+	"iter"
+)
+
+// Result is one entry of a streamed derivation. Index identifies the input
+// (the u64 index seed for DeriveRange); Err is set when derivation failed.
+type Result struct {
+	Index  uint64
+	Output ProgramDerivedAddressOutput
+	Err    error
+}
+
+// DeriveRange lazily derives the PDA for prefixSeeds + u64le(index) for
+// every index in [start, end). This is the common "account #N" layout
+// indexers precompute. If the prefix or program is invalid, a single Result
+// carrying the error is yielded.
+func DeriveRange(program Address, prefixSeeds [][]byte, start, end uint64, opts ...Option) iter.Seq[Result] {
+	return func(yield func(Result) bool) {
+		cfg := newConfig(opts)
+
+		// The index seed is rewritten in place for every iteration
+		var index [8]byte
+		seeds := make([][]byte, len(prefixSeeds)+1)
+		copy(seeds, prefixSeeds)
+		seeds[len(prefixSeeds)] = index[:]
+
+		// The index seed counts towards the limits like any other (need
+		// room for the bump seed)
+		if err := validateSeeds(seeds, 1, cfg); err != nil {
+			yield(Result{Index: start, Err: err})
+			return
+		}
+
+		programIdBytes := [32]byte(program)
+
+		for i := start; i < end; i++ {
+			binary.LittleEndian.PutUint64(index[:], i)
+
+			res := Result{Index: i}
+			digest, bump, err := findProgramAddress(&programIdBytes, seeds, cfg)
+			if err != nil {
+				res.Err = err
+			} else {
+				res.Output = ProgramDerivedAddressOutput{
+					Address: Address(digest),
+					Bump:    bump,
+				}
+			}
+
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package pda
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeriveRange(t *testing.T) {
+	programAddr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+
+	prefix := [][]byte{[]byte("account")}
+
+	next := uint64(10)
+	for res := range DeriveRange(programAddr, prefix, 10, 20) {
+		if res.Err != nil {
+			t.Fatalf("index %d failed: %v", res.Index, res.Err)
+		}
+		if res.Index != next {
+			t.Fatalf("expected index %d, got %d", next, res.Index)
+		}
+		next++
+
+		want, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+			ProgramAddress: programAddr,
+			Seeds:          [][]byte{[]byte("account"), SeedU64LE(res.Index)},
+		})
+		if err != nil {
+			t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+		}
+		if res.Output != want {
+			t.Errorf("index %d: got %+v, want %+v", res.Index, res.Output, want)
+		}
+	}
+
+	if next != 20 {
+		t.Errorf("expected to stop at 20, stopped at %d", next)
+	}
+}
+
+func TestDeriveRange_InvalidPrefix(t *testing.T) {
+	prefix := make([][]byte, MaxSeeds-1) // no room for index + bump
+
+	var results []Result
+	for res := range DeriveRange(Address{}, prefix, 0, 5) {
+		results = append(results, res)
+	}
+
+	if len(results) != 1 || results[0].Err == nil {
+		t.Errorf("expected a single error result, got %+v", results)
+	}
+}
+
+// The 8-byte index seed is checked against custom limits too
+func TestDeriveRange_IndexSeedTooLong(t *testing.T) {
+	prefix := [][]byte{[]byte("acct")}
+
+	var results []Result
+	for res := range DeriveRange(Address{}, prefix, 0, 5, WithLimits(MaxSeeds, 4)) {
+		results = append(results, res)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected a single error result, got %+v", results)
+	}
+	var tooLong ErrSeedTooLong
+	if !errors.As(results[0].Err, &tooLong) || tooLong.Index != 1 || tooLong.Length != 8 {
+		t.Errorf("expected the index seed to be too long, got %v", results[0].Err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package rediscache implements pda.Cache on Redis so horizontally scaled
+// servers share derivation results.
+//
+// It depends only on the small Client interface; wrap whichever Redis
+// library the service already uses, e.g. for go-redis:
+//
+//	type goRedis struct{ *redis.Client }
+//
+//	func (c goRedis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+//		b, err := c.Client.Get(ctx, key).Bytes()
+//		if err == redis.Nil {
+//			return nil, false, nil
+//		}
+//		return b, err == nil, err
+//	}
+//
+//	func (c goRedis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+//		return c.Client.Set(ctx, key, value, ttl).Err()
+//	}
+package rediscache
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// Client is the subset of a Redis client the cache needs. Get reports a
+// missing key with ok == false and a nil error. A ttl of 0 means no expiry.
+type Client interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Options configures a Cache
+type Options struct {
+	// Prefix is prepended to every Redis key, e.g. "pda:"
+	Prefix string
+	// TTL is the expiry of stored results; 0 keeps them forever
+	TTL time.Duration
+	// Timeout bounds each Redis call; 0 means no timeout
+	Timeout time.Duration
+}
+
+// Cache is a pda.Cache backed by Redis. Since pda.Cache methods cannot
+// return errors, a failed Get is reported as a miss and a failed Put is
+// dropped; Err returns the most recent such failure.
+type Cache struct {
+	client Client
+	opts   Options
+
+	mu  sync.Mutex
+	err error
+}
+
+var _ pda.Cache = (*Cache)(nil)
+
+// New creates a Cache using client
+func New(client Client, opts Options) *Cache {
+	return &Cache{client: client, opts: opts}
+}
+
+// Get returns the cached result for key
+func (c *Cache) Get(key string) (pda.ProgramDerivedAddressOutput, bool) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	b, ok, err := c.client.Get(ctx, c.redisKey(key))
+	if err != nil {
+		c.setErr(err)
+		return pda.ProgramDerivedAddressOutput{}, false
+	}
+	if !ok || len(b) != 33 {
+		return pda.ProgramDerivedAddressOutput{}, false
+	}
+
+	out := pda.ProgramDerivedAddressOutput{Address: pda.Address(b[:32]), Bump: b[32]}
+	return out, true
+}
+
+// Put stores out under key with the configured TTL. The value is the 32
+// address bytes followed by the bump.
+func (c *Cache) Put(key string, out pda.ProgramDerivedAddressOutput) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	value := append(out.Address[:], out.Bump)
+	if err := c.client.Set(ctx, c.redisKey(key), value, c.opts.TTL); err != nil {
+		c.setErr(err)
+	}
+}
+
+// Err returns the most recent error swallowed by Get or Put, if any
+func (c *Cache) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// redisKey makes the binary cache key printable so it is easy to inspect
+// with redis-cli
+func (c *Cache) redisKey(key string) string {
+	return c.opts.Prefix + base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func (c *Cache) context() (context.Context, context.CancelFunc) {
+	if c.opts.Timeout > 0 {
+		return context.WithTimeout(context.Background(), c.opts.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func (c *Cache) setErr(err error) {
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+}
@@ -0,0 +1,100 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// fakeClient is an in-memory Client that records TTLs
+type fakeClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	ttls map[string]time.Duration
+	err  error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{data: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (f *fakeClient) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, false, f.err
+	}
+	b, ok := f.data[key]
+	return b, ok, nil
+}
+
+func (f *fakeClient) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.data[key] = append([]byte(nil), value...)
+	f.ttls[key] = ttl
+	return nil
+}
+
+func TestCache_SharedBetweenInstances(t *testing.T) {
+	client := newFakeClient()
+	opts := Options{Prefix: "pda:", TTL: time.Hour}
+	input := pda.ProgramDerivedAddressInput{
+		ProgramAddress: pda.MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("redis")},
+	}
+
+	want, err := pda.GetProgramDerivedAddress(input, pda.WithCache(New(client, opts)))
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	for key, ttl := range client.ttls {
+		if !strings.HasPrefix(key, "pda:") {
+			t.Errorf("expected key prefix, got %q", key)
+		}
+		if ttl != time.Hour {
+			t.Errorf("expected TTL of 1h, got %v", ttl)
+		}
+	}
+
+	// A second "server" with its own Cache sees the stored result
+	attempts := 0
+	hook := pda.WithTraceHook(func(uint8, [32]byte, bool) { attempts++ })
+	got, err := pda.GetProgramDerivedAddress(input, pda.WithCache(New(client, opts)), hook)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if attempts != 0 {
+		t.Errorf("expected a cache hit, got %d attempts", attempts)
+	}
+}
+
+func TestCache_ErrorsAreMisses(t *testing.T) {
+	client := newFakeClient()
+	client.err = errors.New("connection refused")
+	c := New(client, Options{})
+
+	input := pda.ProgramDerivedAddressInput{
+		ProgramAddress: pda.MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("redis")},
+	}
+	if _, err := pda.GetProgramDerivedAddress(input, pda.WithCache(c)); err != nil {
+		t.Fatalf("expected derivation to succeed without Redis, got: %v", err)
+	}
+
+	if !errors.Is(c.Err(), client.err) {
+		t.Errorf("expected Err to report the client error, got: %v", c.Err())
+	}
+}
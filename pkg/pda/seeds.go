@@ -0,0 +1,135 @@
+package pda
+
+import "encoding/binary"
+
+// Seeds builds a seed list with explicit encodings for each value, e.g.
+//
+//	seeds := pda.NewSeeds().String("vault").U64LE(42).Address(owner).Build()
+type Seeds struct {
+	seeds      [][]byte
+	normalized []int
+}
+
+// NewSeeds starts an empty seed list
+func NewSeeds() *Seeds {
+	return &Seeds{}
+}
+
+func (s *Seeds) add(b []byte) *Seeds {
+	s.seeds = append(s.seeds, b)
+	return s
+}
+
+// String appends the UTF-8 bytes of v
+func (s *Seeds) String(v string) *Seeds {
+	return s.add([]byte(v))
+}
+
+// NormalizedString appends the UTF-8 bytes of v in the given normalization
+// form. Seeds whose bytes were changed by normalization are listed by
+// Normalized.
+func (s *Seeds) NormalizedString(v string, form NormalizationForm) *Seeds {
+	b, changed := NormalizeSeed(v, form)
+	if changed {
+		s.normalized = append(s.normalized, len(s.seeds))
+	}
+	return s.add(b)
+}
+
+// Normalized returns the indices of seeds added with NormalizedString whose
+// bytes differ from the input string
+func (s *Seeds) Normalized() []int {
+	return s.normalized
+}
+
+// Bytes appends a copy of b
+func (s *Seeds) Bytes(b []byte) *Seeds {
+	return s.add(append([]byte{}, b...))
+}
+
+// U8 appends a single byte
+func (s *Seeds) U8(v uint8) *Seeds {
+	return s.add(SeedU8(v))
+}
+
+// U16LE appends v as 2 little-endian bytes
+func (s *Seeds) U16LE(v uint16) *Seeds {
+	return s.add(SeedU16LE(v))
+}
+
+// U32LE appends v as 4 little-endian bytes
+func (s *Seeds) U32LE(v uint32) *Seeds {
+	return s.add(SeedU32LE(v))
+}
+
+// U64LE appends v as 8 little-endian bytes
+func (s *Seeds) U64LE(v uint64) *Seeds {
+	return s.add(SeedU64LE(v))
+}
+
+// U128LE appends the 128-bit value hi<<64 | lo as 16 little-endian bytes
+func (s *Seeds) U128LE(hi, lo uint64) *Seeds {
+	return s.add(SeedU128LE(hi, lo))
+}
+
+// Address appends the 32 raw bytes of a
+func (s *Seeds) Address(a Address) *Seeds {
+	return s.add(a[:])
+}
+
+// Build returns the accumulated seeds
+func (s *Seeds) Build() [][]byte {
+	return s.seeds
+}
+
+// --- Numeric seed encodings ---
+//
+// These match the byte layouts produced by Rust's to_le_bytes/to_be_bytes,
+// which is what Anchor programs use for numeric seeds.
+
+// SeedU8 encodes v as a single byte
+func SeedU8(v uint8) []byte {
+	return []byte{v}
+}
+
+// SeedU16LE encodes v as 2 little-endian bytes
+func SeedU16LE(v uint16) []byte {
+	return binary.LittleEndian.AppendUint16(nil, v)
+}
+
+// SeedU16BE encodes v as 2 big-endian bytes
+func SeedU16BE(v uint16) []byte {
+	return binary.BigEndian.AppendUint16(nil, v)
+}
+
+// SeedU32LE encodes v as 4 little-endian bytes
+func SeedU32LE(v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, v)
+}
+
+// SeedU32BE encodes v as 4 big-endian bytes
+func SeedU32BE(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
+
+// SeedU64LE encodes v as 8 little-endian bytes
+func SeedU64LE(v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, v)
+}
+
+// SeedU64BE encodes v as 8 big-endian bytes
+func SeedU64BE(v uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, v)
+}
+
+// SeedU128LE encodes the 128-bit value hi<<64 | lo as 16 little-endian bytes
+func SeedU128LE(hi, lo uint64) []byte {
+	b := binary.LittleEndian.AppendUint64(make([]byte, 0, 16), lo)
+	return binary.LittleEndian.AppendUint64(b, hi)
+}
+
+// SeedU128BE encodes the 128-bit value hi<<64 | lo as 16 big-endian bytes
+func SeedU128BE(hi, lo uint64) []byte {
+	b := binary.BigEndian.AppendUint64(make([]byte, 0, 16), hi)
+	return binary.BigEndian.AppendUint64(b, lo)
+}
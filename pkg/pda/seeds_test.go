@@ -0,0 +1,85 @@
+package pda
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSeedsBuilder(t *testing.T) {
+	owner, err := NewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	if err != nil {
+		t.Fatalf("failed to create owner address: %v", err)
+	}
+	ownerBytes, _ := owner.ToBytes()
+
+	seeds := NewSeeds().
+		String("vault").
+		U64LE(42).
+		U16LE(0x0102).
+		U8(7).
+		Address(owner).
+		Bytes([]byte{0xde, 0xad}).
+		Build()
+
+	want := [][]byte{
+		[]byte("vault"),
+		{42, 0, 0, 0, 0, 0, 0, 0},
+		{0x02, 0x01},
+		{7},
+		ownerBytes[:],
+		{0xde, 0xad},
+	}
+
+	if len(seeds) != len(want) {
+		t.Fatalf("expected %d seeds, got %d", len(want), len(seeds))
+	}
+	for i := range want {
+		if !bytes.Equal(seeds[i], want[i]) {
+			t.Errorf("seed %d: got %x, want %x", i, seeds[i], want[i])
+		}
+	}
+}
+
+func TestNumericSeedEncodings(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want []byte
+	}{
+		{"u8", SeedU8(0xab), []byte{0xab}},
+		{"u16le", SeedU16LE(0x0102), []byte{0x02, 0x01}},
+		{"u16be", SeedU16BE(0x0102), []byte{0x01, 0x02}},
+		{"u32le", SeedU32LE(0x01020304), []byte{0x04, 0x03, 0x02, 0x01}},
+		{"u32be", SeedU32BE(0x01020304), []byte{0x01, 0x02, 0x03, 0x04}},
+		{"u64le", SeedU64LE(1), []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{"u64be", SeedU64BE(1), []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+		{"u128le", SeedU128LE(2, 1), []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}},
+		{"u128be", SeedU128BE(2, 1), []byte{0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		if !bytes.Equal(tt.got, tt.want) {
+			t.Errorf("%s: got %x, want %x", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestSeedsBuilder_NormalizedString(t *testing.T) {
+	b := NewSeeds().
+		NormalizedString(composedE, NFC).
+		String(decomposedE).
+		NormalizedString(decomposedE, NFC)
+
+	seeds := b.Build()
+
+	if !bytes.Equal(seeds[0], seeds[2]) {
+		t.Errorf("expected normalized seeds to match, got %x and %x", seeds[0], seeds[2])
+	}
+	if bytes.Equal(seeds[1], seeds[2]) {
+		t.Error("expected String to leave the decomposed form unchanged")
+	}
+
+	if got := b.Normalized(); len(got) != 1 || got[0] != 2 {
+		t.Errorf("expected Normalized() = [2], got %v", got)
+	}
+}
@@ -0,0 +1,155 @@
+package pda
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/mr-tron/base58"
+)
+
+// ErrInvalidSeedSpec reports which element of a seed spec failed to parse
+type ErrInvalidSeedSpec struct {
+	Index int
+	Spec  string
+	Err   error
+}
+
+func (e ErrInvalidSeedSpec) Error() string {
+	return fmt.Sprintf("seed spec %d (%q): %v", e.Index, e.Spec, e.Err)
+}
+
+func (e ErrInvalidSeedSpec) Unwrap() error {
+	return e.Err
+}
+
+func (e ErrInvalidSeedSpec) Code() ErrorCode {
+	return CodeBadSeedSpec
+}
+
+// ParseSeedSpec parses a compact, comma-separated textual seed list such as
+//
+//	str:vault,u64:42,pubkey:So11111111111111111111111111111111111111112,hex:deadbeef
+//
+// Supported types:
+//
+//	str, string          UTF-8 bytes
+//	nfc, nfkc            UTF-8 bytes after Unicode normalization
+//	u8                   single byte
+//	u16, u32, u64, u128  little-endian integers (the Anchor default)
+//	u16be ... u128be     big-endian integers
+//	pubkey               base58 address, 32 raw bytes
+//	hex, base58, base64  raw bytes in the given encoding
+//
+// Integers accept decimal or 0x-prefixed hex. Values cannot contain commas;
+// use hex or base64 for such strings. An empty spec yields no seeds.
+func ParseSeedSpec(spec string) ([][]byte, error) {
+	if strings.TrimSpace(spec) == "" {
+		return [][]byte{}, nil
+	}
+
+	parts := strings.Split(spec, ",")
+	seeds := make([][]byte, 0, len(parts))
+	for i, part := range parts {
+		seed, err := parseSeed(part)
+		if err != nil {
+			return nil, ErrInvalidSeedSpec{Index: i, Spec: part, Err: err}
+		}
+		seeds = append(seeds, seed)
+	}
+
+	return seeds, nil
+}
+
+// parseSeed parses a single "type:value" element
+func parseSeed(part string) ([]byte, error) {
+	kind, value, ok := strings.Cut(part, ":")
+	if !ok {
+		return nil, errors.New("expected type:value")
+	}
+	return ParseTypedSeed(kind, value)
+}
+
+// ParseTypedSeed encodes value as a seed of the given type, using the
+// types and encodings of ParseSeedSpec. Unlike a seed spec, value may
+// contain commas.
+func ParseTypedSeed(kind, value string) ([]byte, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+
+	switch kind {
+	case "str", "string":
+		return []byte(value), nil
+	case "nfc":
+		b, _ := NormalizeSeed(value, NFC)
+		return b, nil
+	case "nfkc":
+		b, _ := NormalizeSeed(value, NFKC)
+		return b, nil
+	case "u8":
+		v, err := strconv.ParseUint(value, 0, 8)
+		if err != nil {
+			return nil, err
+		}
+		return SeedU8(uint8(v)), nil
+	case "u16", "u16le", "u16be":
+		v, err := strconv.ParseUint(value, 0, 16)
+		if err != nil {
+			return nil, err
+		}
+		if kind == "u16be" {
+			return SeedU16BE(uint16(v)), nil
+		}
+		return SeedU16LE(uint16(v)), nil
+	case "u32", "u32le", "u32be":
+		v, err := strconv.ParseUint(value, 0, 32)
+		if err != nil {
+			return nil, err
+		}
+		if kind == "u32be" {
+			return SeedU32BE(uint32(v)), nil
+		}
+		return SeedU32LE(uint32(v)), nil
+	case "u64", "u64le", "u64be":
+		v, err := strconv.ParseUint(value, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		if kind == "u64be" {
+			return SeedU64BE(v), nil
+		}
+		return SeedU64LE(v), nil
+	case "u128", "u128le", "u128be":
+		v, ok := new(big.Int).SetString(value, 0)
+		if !ok || v.Sign() < 0 || v.BitLen() > 128 {
+			return nil, fmt.Errorf("invalid u128: %q", value)
+		}
+		lo := new(big.Int).And(v, new(big.Int).SetUint64(^uint64(0))).Uint64()
+		hi := new(big.Int).Rsh(v, 64).Uint64()
+		if kind == "u128be" {
+			return SeedU128BE(hi, lo), nil
+		}
+		return SeedU128LE(hi, lo), nil
+	case "pubkey":
+		b, err := DecodeAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		return b[:], nil
+	case "hex":
+		return hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	case "base58":
+		b, err := base58.Decode(value)
+		if err != nil {
+			return nil, ErrInvalidBase58
+		}
+		return b, nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(value)
+	}
+
+	return nil, fmt.Errorf("unknown seed type %q", kind)
+}
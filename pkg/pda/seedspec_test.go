@@ -0,0 +1,99 @@
+package pda
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParseSeedSpec(t *testing.T) {
+	seeds, err := ParseSeedSpec("str:vault,u64:42,pubkey:TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA,hex:deadbeef,u16be:0x0102,u128:1,base64:AQI=")
+	if err != nil {
+		t.Fatalf("ParseSeedSpec failed: %v", err)
+	}
+
+	tokenProgram, _ := DecodeAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	want := [][]byte{
+		[]byte("vault"),
+		SeedU64LE(42),
+		tokenProgram[:],
+		{0xde, 0xad, 0xbe, 0xef},
+		{0x01, 0x02},
+		SeedU128LE(0, 1),
+		{1, 2},
+	}
+
+	if len(seeds) != len(want) {
+		t.Fatalf("expected %d seeds, got %d", len(want), len(seeds))
+	}
+	for i := range want {
+		if !bytes.Equal(seeds[i], want[i]) {
+			t.Errorf("seed %d: got %x, want %x", i, seeds[i], want[i])
+		}
+	}
+}
+
+func TestParseSeedSpec_Normalized(t *testing.T) {
+	seeds, err := ParseSeedSpec("nfc:" + decomposedE + ",nfkc:\ufb01le")
+	if err != nil {
+		t.Fatalf("ParseSeedSpec failed: %v", err)
+	}
+
+	if string(seeds[0]) != composedE || string(seeds[1]) != "file" {
+		t.Errorf("unexpected normalized seeds: %q", seeds)
+	}
+}
+
+func TestParseSeedSpec_Empty(t *testing.T) {
+	seeds, err := ParseSeedSpec("")
+	if err != nil {
+		t.Fatalf("ParseSeedSpec failed: %v", err)
+	}
+	if len(seeds) != 0 {
+		t.Errorf("expected no seeds, got %d", len(seeds))
+	}
+}
+
+func TestParseSeedSpec_Errors(t *testing.T) {
+	tests := []struct {
+		spec  string
+		index int
+	}{
+		{"vault", 0},
+		{"str:ok,u8:256", 1},
+		{"str:ok,str:ok,pubkey:not-base58!", 2},
+		{"float:1.5", 0},
+		{"u128:-1", 0},
+		{"hex:zz", 0},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseSeedSpec(tt.spec)
+
+		var specErr ErrInvalidSeedSpec
+		if !errors.As(err, &specErr) {
+			t.Errorf("%q: expected ErrInvalidSeedSpec, got: %v", tt.spec, err)
+			continue
+		}
+		if specErr.Index != tt.index {
+			t.Errorf("%q: expected error at index %d, got %d", tt.spec, tt.index, specErr.Index)
+		}
+	}
+}
+
+func TestParseTypedSeed(t *testing.T) {
+	got, err := ParseTypedSeed("u64", "42")
+	if err != nil || !bytes.Equal(got, SeedU64LE(42)) {
+		t.Errorf("u64: got %x, %v", got, err)
+	}
+
+	// Values are taken whole, commas included
+	got, err = ParseTypedSeed(" String ", "a,b")
+	if err != nil || string(got) != "a,b" {
+		t.Errorf("string: got %q, %v", got, err)
+	}
+
+	if _, err := ParseTypedSeed("f64", "1"); err == nil {
+		t.Error("expected an error for an unknown type")
+	}
+}
@@ -0,0 +1,176 @@
+package pda
+
+import (
+	"encoding/binary"
+	"iter"
+)
+
+// lanes is the number of bump candidates the multi-buffer SHA-256 path
+// hashes per call. The path is only compiled in with the pdasimd build tag
+// on amd64 and used where the CPU has AVX2 (useMultiBuffer); everywhere
+// else bump searches hash one candidate at a time with crypto/sha256.
+const lanes = 8
+
+// midstateLen is the length of crypto/sha256's marshaled state: a 4-byte
+// magic, the 8 state words, the 64-byte block buffer and the length
+const midstateLen = 4 + 8*4 + 64 + 8
+
+// digests yields every bump of cfg's range with the hash of the seeds and
+// that bump. With the multi-buffer path it hashes lanes candidates per
+// call, ahead of the consumer, which may stop early. The first candidate
+// is hashed on its own: half of all bump searches end there, and a batch
+// would be wasted on them.
+func (b *bumpHasher) digests(cfg *config) iter.Seq2[uint8, [32]byte] {
+	return func(yield func(uint8, [32]byte) bool) {
+		if !useMultiBuffer || !b.prepareLanes() {
+			for bump := range cfg.bumps() {
+				if !yield(bump, b.sum(bump)) {
+					return
+				}
+			}
+			return
+		}
+
+		var (
+			batch [lanes]uint8
+			out   [lanes][32]byte
+			n     int
+			first = true
+		)
+		flush := func() bool {
+			b.sumLanes(&batch, &out)
+			for i := range n {
+				if !yield(batch[i], out[i]) {
+					return false
+				}
+			}
+			n = 0
+			return true
+		}
+		for bump := range cfg.bumps() {
+			if first {
+				if !yield(bump, b.sum(bump)) {
+					return
+				}
+				first = false
+				continue
+			}
+			batch[n] = bump
+			if n++; n == lanes && !flush() {
+				return
+			}
+		}
+		if n > 0 {
+			flush()
+		}
+	}
+}
+
+// prepareLanes splits the saved midstate into the state words and the
+// padded tail that every candidate hashes: the buffered seed bytes, the
+// bump, the program ID, the marker and the SHA-256 padding. It reports
+// false if the midstate is not in the expected format. It runs once per
+// search, as callers such as FindPDAMulti change the program ID in place.
+func (b *bumpHasher) prepareLanes() bool {
+	if len(b.midstate) != midstateLen || string(b.midstate[:4]) != "sha\x03" {
+		return false
+	}
+	for i := range b.state {
+		b.state[i] = binary.BigEndian.Uint32(b.midstate[4+4*i:])
+	}
+	length := binary.BigEndian.Uint64(b.midstate[midstateLen-8:])
+	buffered := int(length % 64)
+
+	// The bump goes right after the buffered seed bytes
+	b.bumpAt = buffered
+	tail := append(b.tail[:0], b.midstate[36:36+buffered]...)
+	tail = append(tail, 0)
+	tail = append(tail, b.programID[:]...)
+	tail = append(tail, b.marker...)
+	bits := (length + uint64(len(tail)-buffered)) * 8
+	tail = append(tail, 0x80)
+	for len(tail)%64 != 56 {
+		tail = append(tail, 0)
+	}
+	b.tail = binary.BigEndian.AppendUint64(tail, bits)
+	return true
+}
+
+// sumLanes hashes the seeds with each of bumps, like sum, into out.
+// prepareLanes must have succeeded.
+func (b *bumpHasher) sumLanes(bumps *[lanes]uint8, out *[lanes][32]byte) {
+	var (
+		h [8][lanes]uint32
+		w [16][lanes]uint32
+	)
+	for i, v := range b.state {
+		for l := range lanes {
+			h[i][l] = v
+		}
+	}
+
+	for block := 0; block < len(b.tail); block += 64 {
+		for i := range w {
+			v := binary.BigEndian.Uint32(b.tail[block+4*i:])
+			for l := range lanes {
+				w[i][l] = v
+			}
+		}
+		if at := b.bumpAt - block; at >= 0 && at < 64 {
+			shift := 24 - 8*(at%4)
+			for l, bump := range bumps {
+				w[at/4][l] = w[at/4][l]&^(0xff<<shift) | uint32(bump)<<shift
+			}
+		}
+		blockX8(&h, &w)
+	}
+
+	for l := range lanes {
+		for i := range h {
+			binary.BigEndian.PutUint32(out[l][4*i:], h[i][l])
+		}
+	}
+}
+
+var sha256K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+// blockX8Generic is the portable reference for blockX8: the SHA-256
+// compression function over each lane of the transposed state and block
+func blockX8Generic(h *[8][lanes]uint32, w *[16][lanes]uint32) {
+	rotr := func(x uint32, n uint) uint32 { return x>>n | x<<(32-n) }
+	for l := range lanes {
+		var s [64]uint32
+		for i := range 16 {
+			s[i] = w[i][l]
+		}
+		for i := 16; i < 64; i++ {
+			s0 := rotr(s[i-15], 7) ^ rotr(s[i-15], 18) ^ s[i-15]>>3
+			s1 := rotr(s[i-2], 17) ^ rotr(s[i-2], 19) ^ s[i-2]>>10
+			s[i] = s1 + s[i-7] + s0 + s[i-16]
+		}
+
+		a, b, c, d, e, f, g, hh := h[0][l], h[1][l], h[2][l], h[3][l], h[4][l], h[5][l], h[6][l], h[7][l]
+		for i := range 64 {
+			t1 := hh + (rotr(e, 6) ^ rotr(e, 11) ^ rotr(e, 25)) + (e&f ^ ^e&g) + sha256K[i] + s[i]
+			t2 := (rotr(a, 2) ^ rotr(a, 13) ^ rotr(a, 22)) + (a&b | c&(a|b))
+			a, b, c, d, e, f, g, hh = t1+t2, a, b, c, d+t1, e, f, g
+		}
+		h[0][l] += a
+		h[1][l] += b
+		h[2][l] += c
+		h[3][l] += d
+		h[4][l] += e
+		h[5][l] += f
+		h[6][l] += g
+		h[7][l] += hh
+	}
+}
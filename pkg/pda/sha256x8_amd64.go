@@ -0,0 +1,22 @@
+//go:build pdasimd && amd64
+
+package pda
+
+import "golang.org/x/sys/cpu"
+
+// useMultiBuffer enables the AVX2 multi-buffer path of bump searches
+var useMultiBuffer = cpu.X86.HasAVX2
+
+// blockX8AVX2 is blockX8 in AVX2 assembly
+//
+//go:noescape
+func blockX8AVX2(h *[8][lanes]uint32, w *[16][lanes]uint32)
+
+// blockX8 runs the SHA-256 compression function on lanes messages at once
+func blockX8(h *[8][lanes]uint32, w *[16][lanes]uint32) {
+	if useMultiBuffer {
+		blockX8AVX2(h, w)
+		return
+	}
+	blockX8Generic(h, w)
+}
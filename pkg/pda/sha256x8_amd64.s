@@ -0,0 +1,243 @@
+//go:build pdasimd && amd64
+
+#include "textflag.h"
+
+// blockX8AVX2 runs the SHA-256 compression function on eight independent
+// messages at once, one per 32-bit lane of the YMM registers. The state and
+// the message words are transposed: h[i] holds word i of every lane's state
+// and w[i] word i of every lane's block, already in host byte order.
+//
+// Y0-Y7 hold a-h, Y8-Y12 are scratch and R8 points at the round constants.
+// The message schedule W[0..63] lives in the 2KB frame, 32 bytes per word.
+
+// ROTR sets dst to x rotated right by n in every lane, clobbering tmp
+#define ROTR(n, x, dst, tmp) \
+	VPSRLD $n, x, dst; \
+	VPSLLD $(32-n), x, tmp; \
+	VPOR   tmp, dst, dst
+
+// ROUND runs round t: T1 = h + Σ1(e) + Ch(e, f, g) + K[t] + W[t] and
+// T2 = Σ0(a) + Maj(a, b, c), then d += T1 and h = T1 + T2. The callers
+// rotate the register names instead of moving the state.
+#define ROUND(a, b, c, d, e, f, g, h, t) \
+	ROTR(6, e, Y8, Y9); \
+	ROTR(11, e, Y10, Y9); \
+	VPXOR        Y10, Y8, Y8; \
+	ROTR(25, e, Y10, Y9); \
+	VPXOR        Y10, Y8, Y8; \
+	VPAND        f, e, Y9; \
+	VPANDN       g, e, Y10; \
+	VPXOR        Y10, Y9, Y9; \
+	VPADDD       Y9, Y8, Y8; \
+	VPADDD       h, Y8, Y8; \
+	VPBROADCASTD (t*4)(R8), Y9; \
+	VPADDD       Y9, Y8, Y8; \
+	VPADDD       (t*32)(SP), Y8, Y8; \
+	VPADDD       Y8, d, d; \
+	ROTR(2, a, Y9, Y10); \
+	ROTR(13, a, Y10, Y11); \
+	VPXOR        Y10, Y9, Y9; \
+	ROTR(22, a, Y10, Y11); \
+	VPXOR        Y10, Y9, Y9; \
+	VPOR         b, a, Y10; \
+	VPAND        c, Y10, Y10; \
+	VPAND        b, a, Y11; \
+	VPOR         Y11, Y10, Y10; \
+	VPADDD       Y10, Y9, Y9; \
+	VPADDD       Y9, Y8, h
+
+// func blockX8AVX2(h *[8][lanes]uint32, w *[16][lanes]uint32)
+TEXT ·blockX8AVX2(SB), 0, $2048-16
+	MOVQ h+0(FP), AX
+	MOVQ w+8(FP), BX
+	LEAQ sha256K<>(SB), R8
+
+	// W[0..15] are the message words
+	MOVQ $0, CX
+copy:
+	VMOVDQU (BX)(CX*1), Y8
+	VMOVDQU Y8, (SP)(CX*1)
+	ADDQ    $32, CX
+	CMPQ    CX, $(16*32)
+	JNE     copy
+
+	// W[t] = σ1(W[t-2]) + W[t-7] + σ0(W[t-15]) + W[t-16]
+schedule:
+	VMOVDQU -(2*32)(SP)(CX*1), Y11
+	ROTR(17, Y11, Y8, Y9)
+	ROTR(19, Y11, Y10, Y9)
+	VPXOR   Y10, Y8, Y8
+	VPSRLD  $10, Y11, Y10
+	VPXOR   Y10, Y8, Y8
+	VMOVDQU -(15*32)(SP)(CX*1), Y11
+	ROTR(7, Y11, Y9, Y10)
+	ROTR(18, Y11, Y10, Y12)
+	VPXOR   Y10, Y9, Y9
+	VPSRLD  $3, Y11, Y10
+	VPXOR   Y10, Y9, Y9
+	VPADDD  Y9, Y8, Y8
+	VPADDD  -(7*32)(SP)(CX*1), Y8, Y8
+	VPADDD  -(16*32)(SP)(CX*1), Y8, Y8
+	VMOVDQU Y8, (SP)(CX*1)
+	ADDQ    $32, CX
+	CMPQ    CX, $(64*32)
+	JNE     schedule
+
+	VMOVDQU (0*32)(AX), Y0
+	VMOVDQU (1*32)(AX), Y1
+	VMOVDQU (2*32)(AX), Y2
+	VMOVDQU (3*32)(AX), Y3
+	VMOVDQU (4*32)(AX), Y4
+	VMOVDQU (5*32)(AX), Y5
+	VMOVDQU (6*32)(AX), Y6
+	VMOVDQU (7*32)(AX), Y7
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 0)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 1)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 2)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 3)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 4)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 5)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 6)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 7)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 8)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 9)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 10)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 11)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 12)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 13)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 14)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 15)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 16)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 17)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 18)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 19)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 20)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 21)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 22)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 23)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 24)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 25)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 26)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 27)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 28)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 29)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 30)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 31)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 32)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 33)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 34)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 35)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 36)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 37)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 38)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 39)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 40)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 41)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 42)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 43)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 44)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 45)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 46)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 47)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 48)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 49)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 50)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 51)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 52)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 53)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 54)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 55)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 56)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 57)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 58)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 59)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 60)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 61)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 62)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 63)
+
+	// Add the rounds' result to the incoming state
+	VPADDD  (0*32)(AX), Y0, Y0
+	VPADDD  (1*32)(AX), Y1, Y1
+	VPADDD  (2*32)(AX), Y2, Y2
+	VPADDD  (3*32)(AX), Y3, Y3
+	VPADDD  (4*32)(AX), Y4, Y4
+	VPADDD  (5*32)(AX), Y5, Y5
+	VPADDD  (6*32)(AX), Y6, Y6
+	VPADDD  (7*32)(AX), Y7, Y7
+	VMOVDQU Y0, (0*32)(AX)
+	VMOVDQU Y1, (1*32)(AX)
+	VMOVDQU Y2, (2*32)(AX)
+	VMOVDQU Y3, (3*32)(AX)
+	VMOVDQU Y4, (4*32)(AX)
+	VMOVDQU Y5, (5*32)(AX)
+	VMOVDQU Y6, (6*32)(AX)
+	VMOVDQU Y7, (7*32)(AX)
+	VZEROUPPER
+	RET
+
+DATA sha256K<>+0x00(SB)/4, $0x428a2f98
+DATA sha256K<>+0x04(SB)/4, $0x71374491
+DATA sha256K<>+0x08(SB)/4, $0xb5c0fbcf
+DATA sha256K<>+0x0c(SB)/4, $0xe9b5dba5
+DATA sha256K<>+0x10(SB)/4, $0x3956c25b
+DATA sha256K<>+0x14(SB)/4, $0x59f111f1
+DATA sha256K<>+0x18(SB)/4, $0x923f82a4
+DATA sha256K<>+0x1c(SB)/4, $0xab1c5ed5
+DATA sha256K<>+0x20(SB)/4, $0xd807aa98
+DATA sha256K<>+0x24(SB)/4, $0x12835b01
+DATA sha256K<>+0x28(SB)/4, $0x243185be
+DATA sha256K<>+0x2c(SB)/4, $0x550c7dc3
+DATA sha256K<>+0x30(SB)/4, $0x72be5d74
+DATA sha256K<>+0x34(SB)/4, $0x80deb1fe
+DATA sha256K<>+0x38(SB)/4, $0x9bdc06a7
+DATA sha256K<>+0x3c(SB)/4, $0xc19bf174
+DATA sha256K<>+0x40(SB)/4, $0xe49b69c1
+DATA sha256K<>+0x44(SB)/4, $0xefbe4786
+DATA sha256K<>+0x48(SB)/4, $0x0fc19dc6
+DATA sha256K<>+0x4c(SB)/4, $0x240ca1cc
+DATA sha256K<>+0x50(SB)/4, $0x2de92c6f
+DATA sha256K<>+0x54(SB)/4, $0x4a7484aa
+DATA sha256K<>+0x58(SB)/4, $0x5cb0a9dc
+DATA sha256K<>+0x5c(SB)/4, $0x76f988da
+DATA sha256K<>+0x60(SB)/4, $0x983e5152
+DATA sha256K<>+0x64(SB)/4, $0xa831c66d
+DATA sha256K<>+0x68(SB)/4, $0xb00327c8
+DATA sha256K<>+0x6c(SB)/4, $0xbf597fc7
+DATA sha256K<>+0x70(SB)/4, $0xc6e00bf3
+DATA sha256K<>+0x74(SB)/4, $0xd5a79147
+DATA sha256K<>+0x78(SB)/4, $0x06ca6351
+DATA sha256K<>+0x7c(SB)/4, $0x14292967
+DATA sha256K<>+0x80(SB)/4, $0x27b70a85
+DATA sha256K<>+0x84(SB)/4, $0x2e1b2138
+DATA sha256K<>+0x88(SB)/4, $0x4d2c6dfc
+DATA sha256K<>+0x8c(SB)/4, $0x53380d13
+DATA sha256K<>+0x90(SB)/4, $0x650a7354
+DATA sha256K<>+0x94(SB)/4, $0x766a0abb
+DATA sha256K<>+0x98(SB)/4, $0x81c2c92e
+DATA sha256K<>+0x9c(SB)/4, $0x92722c85
+DATA sha256K<>+0xa0(SB)/4, $0xa2bfe8a1
+DATA sha256K<>+0xa4(SB)/4, $0xa81a664b
+DATA sha256K<>+0xa8(SB)/4, $0xc24b8b70
+DATA sha256K<>+0xac(SB)/4, $0xc76c51a3
+DATA sha256K<>+0xb0(SB)/4, $0xd192e819
+DATA sha256K<>+0xb4(SB)/4, $0xd6990624
+DATA sha256K<>+0xb8(SB)/4, $0xf40e3585
+DATA sha256K<>+0xbc(SB)/4, $0x106aa070
+DATA sha256K<>+0xc0(SB)/4, $0x19a4c116
+DATA sha256K<>+0xc4(SB)/4, $0x1e376c08
+DATA sha256K<>+0xc8(SB)/4, $0x2748774c
+DATA sha256K<>+0xcc(SB)/4, $0x34b0bcb5
+DATA sha256K<>+0xd0(SB)/4, $0x391c0cb3
+DATA sha256K<>+0xd4(SB)/4, $0x4ed8aa4a
+DATA sha256K<>+0xd8(SB)/4, $0x5b9cca4f
+DATA sha256K<>+0xdc(SB)/4, $0x682e6ff3
+DATA sha256K<>+0xe0(SB)/4, $0x748f82ee
+DATA sha256K<>+0xe4(SB)/4, $0x78a5636f
+DATA sha256K<>+0xe8(SB)/4, $0x84c87814
+DATA sha256K<>+0xec(SB)/4, $0x8cc70208
+DATA sha256K<>+0xf0(SB)/4, $0x90befffa
+DATA sha256K<>+0xf4(SB)/4, $0xa4506ceb
+DATA sha256K<>+0xf8(SB)/4, $0xbef9a3f7
+DATA sha256K<>+0xfc(SB)/4, $0xc67178f2
+GLOBL sha256K<>(SB), RODATA|NOPTR, $256
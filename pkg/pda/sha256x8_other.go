@@ -0,0 +1,12 @@
+//go:build !pdasimd || !amd64
+
+package pda
+
+// useMultiBuffer is false without the pdasimd tag on amd64: bump searches
+// hash one candidate at a time with crypto/sha256
+const useMultiBuffer = false
+
+// blockX8 runs the SHA-256 compression function on lanes messages at once
+func blockX8(h *[8][lanes]uint32, w *[16][lanes]uint32) {
+	blockX8Generic(h, w)
+}
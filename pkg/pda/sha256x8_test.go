@@ -0,0 +1,98 @@
+package pda
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+)
+
+var sha256IV = [8]uint32{0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19}
+
+// Each lane hashes its own single-block message
+func TestBlockX8(t *testing.T) {
+	var msgs [lanes][]byte
+	var h [8][lanes]uint32
+	var w [16][lanes]uint32
+	for l := range lanes {
+		msgs[l] = bytes.Repeat([]byte{byte(l + 1)}, 7*l)
+
+		block := append(bytes.Clone(msgs[l]), 0x80)
+		block = append(block, make([]byte, 56-len(block))...)
+		block = binary.BigEndian.AppendUint64(block, uint64(len(msgs[l]))*8)
+		for i := range w {
+			w[i][l] = binary.BigEndian.Uint32(block[4*i:])
+		}
+		for i := range h {
+			h[i][l] = sha256IV[i]
+		}
+	}
+
+	for name, block := range map[string]func(*[8][lanes]uint32, *[16][lanes]uint32){
+		"blockX8":        blockX8,
+		"blockX8Generic": blockX8Generic,
+	} {
+		h, w := h, w
+		block(&h, &w)
+		for l := range lanes {
+			var got [32]byte
+			for i := range h {
+				binary.BigEndian.PutUint32(got[4*i:], h[i][l])
+			}
+			if want := sha256.Sum256(msgs[l]); got != want {
+				t.Errorf("%s: lane %d: got %x, want %x", name, l, got, want)
+			}
+		}
+	}
+}
+
+// The tail spans one to three blocks depending on the buffered seed bytes
+// and the marker; every lane must match the one-at-a-time hash
+func TestBumpHasher_SumLanes(t *testing.T) {
+	programID := [32]byte(MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111"))
+	bumps := [lanes]uint8{255, 254, 200, 128, 64, 9, 1, 0}
+
+	for _, marker := range [][]byte{pdaMarkerBytes, nil, bytes.Repeat([]byte("m"), 90)} {
+		for seedLen := 0; seedLen <= 130; seedLen++ {
+			seeds := [][]byte{bytes.Repeat([]byte{0xab}, seedLen)}
+			bh := newBumpHasher(&programID, seeds, marker)
+			if !bh.prepareLanes() {
+				t.Fatal("prepareLanes rejected crypto/sha256's midstate")
+			}
+
+			var out [lanes][32]byte
+			bh.sumLanes(&bumps, &out)
+			for l, bump := range bumps {
+				if want := bh.sum(bump); out[l] != want {
+					t.Errorf("marker %d bytes, seed %d bytes, bump %d: got %x, want %x", len(marker), seedLen, bump, out[l], want)
+				}
+			}
+			bh.release()
+		}
+	}
+}
+
+func BenchmarkBumpHasher_Sum(b *testing.B) {
+	programID := [32]byte(MustNewAddress("11111111111111111111111111111111"))
+	bh := newBumpHasher(&programID, [][]byte{[]byte("bench")}, pdaMarkerBytes)
+	defer bh.release()
+
+	for b.Loop() {
+		for bump := range uint8(lanes) {
+			bh.sum(bump)
+		}
+	}
+}
+
+func BenchmarkBumpHasher_SumLanes(b *testing.B) {
+	programID := [32]byte(MustNewAddress("11111111111111111111111111111111"))
+	bh := newBumpHasher(&programID, [][]byte{[]byte("bench")}, pdaMarkerBytes)
+	defer bh.release()
+	bh.prepareLanes()
+
+	var bumps [lanes]uint8
+	var out [lanes][32]byte
+	for b.Loop() {
+		bh.sumLanes(&bumps, &out)
+	}
+}
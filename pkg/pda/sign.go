@@ -0,0 +1,29 @@
+package pda
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+)
+
+// VerifySignature reports whether signature is a valid ed25519 signature of
+// message by the key behind addr. Off-curve addresses such as PDAs have no
+// private key, so they never verify. A malformed signature is an error.
+func VerifySignature(addr Address, message, signature []byte) (bool, error) {
+	if len(signature) != ed25519.SignatureSize {
+		return false, fmt.Errorf("invalid signature length: %d (want: %d)", len(signature), ed25519.SignatureSize)
+	}
+	return ed25519.Verify(addr[:], message, signature), nil
+}
+
+// Sign returns the ed25519 signature of message by kp
+func Sign(kp Keypair, message []byte) []byte {
+	return ed25519.Sign(kp.PrivateKey, message)
+}
+
+// SignBase58 returns the signature of message by kp, base58-encoded as
+// Solana wallets and RPC nodes display signatures
+func SignBase58(kp Keypair, message []byte) string {
+	return base58.Encode(Sign(kp, message))
+}
@@ -0,0 +1,55 @@
+package pda
+
+import (
+	"testing"
+
+	"github.com/mr-tron/base58"
+)
+
+func TestVerifySignature(t *testing.T) {
+	kp, err := GenerateKeypair()
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+
+	message := []byte("sign in to example.com")
+	signature := Sign(kp, message)
+
+	ok, err := VerifySignature(kp.Address(), message, signature)
+	if err != nil || !ok {
+		t.Errorf("expected valid signature to verify (err: %v)", err)
+	}
+
+	ok, err = VerifySignature(kp.Address(), []byte("tampered"), signature)
+	if err != nil || ok {
+		t.Errorf("expected tampered message not to verify (err: %v)", err)
+	}
+
+	other, _ := GenerateKeypair()
+	ok, err = VerifySignature(other.Address(), message, signature)
+	if err != nil || ok {
+		t.Errorf("expected other key not to verify (err: %v)", err)
+	}
+
+	if _, err := VerifySignature(kp.Address(), message, signature[:63]); err == nil {
+		t.Error("expected error for short signature")
+	}
+}
+
+func TestSignBase58(t *testing.T) {
+	kp, err := GenerateKeypair()
+	if err != nil {
+		t.Fatalf("GenerateKeypair failed: %v", err)
+	}
+
+	message := []byte("hello")
+	signature, err := base58.Decode(SignBase58(kp, message))
+	if err != nil {
+		t.Fatalf("signature is not valid base58: %v", err)
+	}
+
+	ok, err := VerifySignature(kp.Address(), message, signature)
+	if err != nil || !ok {
+		t.Errorf("expected base58 signature to verify (err: %v)", err)
+	}
+}
@@ -0,0 +1,157 @@
+//go:build !js
+
+// Package sqlitecache implements pda.Cache on a local SQLite database, so
+// bulk jobs can dedup derivations across runs without external services.
+// The driver is pure Go, but it is unavailable under js/wasm.
+package sqlitecache
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+
+	"raccoon-wasm/pkg/pda"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS pda_cache (
+	key     BLOB PRIMARY KEY,
+	address BLOB NOT NULL,
+	bump    INTEGER NOT NULL,
+	used_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS pda_cache_used_at ON pda_cache (used_at);
+`
+
+// Cache is a pda.Cache backed by a SQLite table. Since pda.Cache methods
+// cannot return errors, a failed Get is reported as a miss and a failed Put
+// is dropped; Err returns the most recent such failure.
+type Cache struct {
+	db  *sql.DB
+	own bool
+
+	mu  sync.Mutex
+	err error
+}
+
+var _ pda.Cache = (*Cache)(nil)
+
+// Open opens (or creates) the SQLite file at path and ensures the schema
+// exists
+func Open(path string) (*Cache, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	c.own = true
+	return c, nil
+}
+
+// New uses an existing database handle, creating the table if needed. The
+// caller keeps ownership of db; Close does not close it.
+func New(db *sql.DB) (*Cache, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+	return &Cache{db: db}, nil
+}
+
+// Get returns the cached result for key and refreshes its last-used time
+func (c *Cache) Get(key string) (pda.ProgramDerivedAddressOutput, bool) {
+	var addr []byte
+	var bump int
+	err := c.db.QueryRow(`SELECT address, bump FROM pda_cache WHERE key = ?`, []byte(key)).Scan(&addr, &bump)
+	if err == sql.ErrNoRows {
+		return pda.ProgramDerivedAddressOutput{}, false
+	}
+	if err != nil {
+		c.setErr(err)
+		return pda.ProgramDerivedAddressOutput{}, false
+	}
+
+	out := pda.ProgramDerivedAddressOutput{Bump: uint8(bump)}
+	if copy(out.Address[:], addr) != len(out.Address) {
+		return pda.ProgramDerivedAddressOutput{}, false
+	}
+
+	if _, err := c.db.Exec(`UPDATE pda_cache SET used_at = ? WHERE key = ?`, now(), []byte(key)); err != nil {
+		c.setErr(err)
+	}
+	return out, true
+}
+
+// Put stores out under key
+func (c *Cache) Put(key string, out pda.ProgramDerivedAddressOutput) {
+	_, err := c.db.Exec(
+		`INSERT INTO pda_cache (key, address, bump, used_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT (key) DO UPDATE SET address = excluded.address, bump = excluded.bump, used_at = excluded.used_at`,
+		[]byte(key), out.Address[:], int(out.Bump), now(),
+	)
+	if err != nil {
+		c.setErr(err)
+	}
+}
+
+// Len returns the number of cached results
+func (c *Cache) Len() (int, error) {
+	var n int
+	err := c.db.QueryRow(`SELECT COUNT(*) FROM pda_cache`).Scan(&n)
+	return n, err
+}
+
+// Prune keeps the maxEntries most recently used results and deletes the
+// rest, returning how many were removed
+func (c *Cache) Prune(maxEntries int) (int64, error) {
+	res, err := c.db.Exec(
+		`DELETE FROM pda_cache WHERE key NOT IN (
+			SELECT key FROM pda_cache ORDER BY used_at DESC LIMIT ?
+		)`,
+		maxEntries,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// PruneOlderThan deletes results not used within age, returning how many
+// were removed
+func (c *Cache) PruneOlderThan(age time.Duration) (int64, error) {
+	res, err := c.db.Exec(`DELETE FROM pda_cache WHERE used_at < ?`, time.Now().Add(-age).UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Err returns the most recent error swallowed by Get or Put, if any
+func (c *Cache) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close closes the database if it was opened by Open
+func (c *Cache) Close() error {
+	if c.own {
+		return c.db.Close()
+	}
+	return nil
+}
+
+func (c *Cache) setErr(err error) {
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+}
+
+func now() int64 {
+	return time.Now().UnixNano()
+}
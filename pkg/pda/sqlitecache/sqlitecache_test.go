@@ -0,0 +1,89 @@
+//go:build !js
+
+package sqlitecache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+func TestCache_PersistsAcrossOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pda.db")
+	input := pda.ProgramDerivedAddressInput{
+		ProgramAddress: pda.MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("sqlite")},
+	}
+
+	c, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	want, err := pda.GetProgramDerivedAddress(input, pda.WithCache(c))
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	c, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer c.Close()
+
+	attempts := 0
+	hook := pda.WithTraceHook(func(uint8, [32]byte, bool) { attempts++ })
+	got, err := pda.GetProgramDerivedAddress(input, pda.WithCache(c), hook)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if attempts != 0 {
+		t.Errorf("expected a cache hit after reopening, got %d attempts", attempts)
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("unexpected cache error: %v", err)
+	}
+}
+
+func TestCache_Prune(t *testing.T) {
+	c, err := Open(filepath.Join(t.TempDir(), "pda.db"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer c.Close()
+
+	for _, key := range []string{"a", "b", "c"} {
+		c.Put(key, pda.ProgramDerivedAddressOutput{Bump: 1})
+	}
+	// Touch "a" so it is the most recently used
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a hit for a")
+	}
+
+	removed, err := c.Prune(1)
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("expected 2 removed, got %d", removed)
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected the most recently used entry to survive")
+	}
+
+	removed, err = c.PruneOlderThan(-time.Hour)
+	if err != nil {
+		t.Fatalf("PruneOlderThan failed: %v", err)
+	}
+	if n, _ := c.Len(); removed != 1 || n != 0 {
+		t.Errorf("expected the cache to be emptied, removed %d, %d left", removed, n)
+	}
+}
@@ -0,0 +1,41 @@
+package pda
+
+// DerivationStats describes the work a bump search did
+type DerivationStats struct {
+	// Attempts is the number of bumps hashed, including the winning one
+	Attempts int `json:"attempts"`
+	// Rejected lists, in search order, the bumps whose hash landed on the
+	// curve before a viable bump was found
+	Rejected []uint8 `json:"rejected"`
+}
+
+// FindPDAWithStats is GetProgramDerivedAddress plus a record of every bump
+// tried. It explains why the canonical bump is, say, 253: bumps 255 and 254
+// were rejected as on-curve. On ErrNoViableBump the stats cover the whole
+// searched range.
+func FindPDAWithStats(input ProgramDerivedAddressInput, opts ...Option) (ProgramDerivedAddressOutput, DerivationStats, error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(input.Seeds, 1, cfg); err != nil {
+		return ProgramDerivedAddressOutput{}, DerivationStats{}, err
+	}
+
+	programIdBytes := [32]byte(input.ProgramAddress)
+
+	stats := DerivationStats{Rejected: []uint8{}}
+	bh := newBumpHasher(&programIdBytes, input.Seeds, cfg.marker)
+	defer bh.release()
+
+	for bump := range cfg.bumps() {
+		stats.Attempts++
+		digest, onCurve := tryBump(bh, bump, cfg)
+		if onCurve {
+			stats.Rejected = append(stats.Rejected, bump)
+			continue
+		}
+		return ProgramDerivedAddressOutput{Address: Address(digest), Bump: bump}, stats, nil
+	}
+
+	return ProgramDerivedAddressOutput{}, stats, ErrNoViableBump{}
+}
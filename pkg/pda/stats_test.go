@@ -0,0 +1,63 @@
+package pda
+
+import (
+	"errors"
+	"testing"
+)
+
+// "stats-9" has canonical bump 250, so five bumps are rejected first
+func TestFindPDAWithStats(t *testing.T) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("stats-9")},
+	}
+
+	want, err := GetProgramDerivedAddress(input)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	got, stats, err := FindPDAWithStats(input)
+	if err != nil {
+		t.Fatalf("FindPDAWithStats failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if want.Bump != 250 {
+		t.Fatalf("expected canonical bump 250, got %d", want.Bump)
+	}
+	if stats.Attempts != 256-int(want.Bump) {
+		t.Errorf("expected %d attempts, got %d", 256-int(want.Bump), stats.Attempts)
+	}
+	if len(stats.Rejected) != stats.Attempts-1 {
+		t.Errorf("expected %d rejected bumps, got %v", stats.Attempts-1, stats.Rejected)
+	}
+	for i, bump := range stats.Rejected {
+		if bump != uint8(255-i) {
+			t.Errorf("rejected[%d] = %d, want %d", i, bump, 255-i)
+		}
+	}
+}
+
+func TestFindPDAWithStats_NoViableBump(t *testing.T) {
+	input := ProgramDerivedAddressInput{
+		ProgramAddress: MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),
+		Seeds:          [][]byte{[]byte("stats-9")},
+	}
+
+	canonical, err := GetProgramDerivedAddress(input)
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+	_, stats, err := FindPDAWithStats(input, WithBumpRange(255, canonical.Bump+1))
+
+	var noBumpErr ErrNoViableBump
+	if !errors.As(err, &noBumpErr) {
+		t.Fatalf("expected ErrNoViableBump, got: %v", err)
+	}
+	if stats.Attempts != len(stats.Rejected) || stats.Attempts != 255-int(canonical.Bump) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,122 @@
+package pda
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format selects the serialization used by StreamResults
+type Format int
+
+const (
+	// NDJSON writes one JSON object per line
+	NDJSON Format = iota
+	// CSV writes a header row followed by one row per result
+	CSV
+)
+
+// String returns the name accepted by ParseFormat
+func (f Format) String() string {
+	switch f {
+	case NDJSON:
+		return "ndjson"
+	case CSV:
+		return "csv"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// ParseFormat accepts "ndjson" (or "jsonl") and "csv", case-insensitively
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "ndjson", "jsonl":
+		return NDJSON, nil
+	case "csv":
+		return CSV, nil
+	}
+	return 0, fmt.Errorf("unknown output format %q (want ndjson or csv)", s)
+}
+
+// csvHeader is the fixed column order of CSV output
+var csvHeader = []string{"index", "address", "bump", "error", "code"}
+
+// resultRecord is the NDJSON shape of a Result; field order is fixed by
+// the struct
+type resultRecord struct {
+	Index   uint64    `json:"index"`
+	Address *Address  `json:"address,omitempty"`
+	Bump    *uint8    `json:"bump,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Code    ErrorCode `json:"code,omitempty"`
+}
+
+// StreamResults writes every Result received from results to w as it
+// arrives, until the channel is closed. Output is buffered but flushed
+// whenever no result is waiting, so a slow producer's rows are not held
+// back. Failed results carry their error message and ErrorCode; successful
+// ones their address and bump. Only write errors are returned; after one,
+// the remaining results are drained and discarded so the producer is not
+// blocked.
+func StreamResults(w io.Writer, format Format, results <-chan Result) error {
+	bw := bufio.NewWriter(w)
+
+	var write func(Result) error
+	switch format {
+	case NDJSON:
+		enc := json.NewEncoder(bw)
+		write = func(r Result) error {
+			rec := resultRecord{Index: r.Index}
+			if r.Err != nil {
+				rec.Error, rec.Code = r.Err.Error(), ErrorCodeOf(r.Err)
+			} else {
+				rec.Address, rec.Bump = &r.Output.Address, &r.Output.Bump
+			}
+			return enc.Encode(rec)
+		}
+	case CSV:
+		cw := csv.NewWriter(bw)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		row := make([]string, len(csvHeader))
+		write = func(r Result) error {
+			row[0] = strconv.FormatUint(r.Index, 10)
+			if r.Err != nil {
+				row[1], row[2], row[3], row[4] = "", "", r.Err.Error(), string(ErrorCodeOf(r.Err))
+			} else {
+				row[1], row[2], row[3], row[4] = r.Output.Address.String(), strconv.Itoa(int(r.Output.Bump)), "", ""
+			}
+			cw.Write(row)
+			// Push the row into bw so the flush below sees it
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		return fmt.Errorf("unknown output format %v", format)
+	}
+
+	for r := range results {
+		if err := write(r); err != nil {
+			drain(results)
+			return err
+		}
+		if len(results) == 0 {
+			if err := bw.Flush(); err != nil {
+				drain(results)
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// drain discards the remaining results
+func drain(results <-chan Result) {
+	for range results {
+	}
+}
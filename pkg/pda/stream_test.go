@@ -0,0 +1,103 @@
+package pda
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func streamResults() []Result {
+	var results []Result
+	for r := range DeriveRange(MustNewAddress("11111111111111111111111111111111"), [][]byte{[]byte("stream")}, 0, 3) {
+		results = append(results, r)
+	}
+	return append(results, Result{Index: 3, Err: ErrNoViableBump{}})
+}
+
+// feed sends results on a buffered channel and closes it
+func feed(results []Result) <-chan Result {
+	ch := make(chan Result, len(results))
+	for _, r := range results {
+		ch <- r
+	}
+	close(ch)
+	return ch
+}
+
+func TestStreamResults_NDJSON(t *testing.T) {
+	results := streamResults()
+
+	var buf bytes.Buffer
+	if err := StreamResults(&buf, NDJSON, feed(results)); err != nil {
+		t.Fatalf("StreamResults failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(results) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(results), len(lines), buf.String())
+	}
+
+	want := `{"index":0,"address":"` + results[0].Output.Address.String() + `","bump":`
+	if !strings.HasPrefix(lines[0], want) {
+		t.Errorf("unexpected first line %s", lines[0])
+	}
+
+	var rec struct {
+		Index uint64
+		Error string
+		Code  ErrorCode
+	}
+	if err := json.Unmarshal([]byte(lines[3]), &rec); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if rec.Index != 3 || rec.Code != CodeNoViableBump || rec.Error == "" {
+		t.Errorf("unexpected error line %s", lines[3])
+	}
+}
+
+func TestStreamResults_CSV(t *testing.T) {
+	results := streamResults()
+
+	var buf bytes.Buffer
+	if err := StreamResults(&buf, CSV, feed(results)); err != nil {
+		t.Fatalf("StreamResults failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "index,address,bump,error,code" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if want := "1," + results[1].Output.Address.String() + ","; !strings.HasPrefix(lines[2], want) {
+		t.Errorf("expected row %q to start with %q", lines[2], want)
+	}
+	if !strings.HasSuffix(lines[4], ","+string(CodeNoViableBump)) {
+		t.Errorf("unexpected error row %q", lines[4])
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestStreamResults_WriteError(t *testing.T) {
+	if err := StreamResults(failingWriter{}, NDJSON, feed(streamResults())); err == nil {
+		t.Error("expected the write error to be returned")
+	}
+	if err := StreamResults(&bytes.Buffer{}, Format(9), feed(nil)); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for s, want := range map[string]Format{"ndjson": NDJSON, "JSONL": NDJSON, "csv": CSV} {
+		got, err := ParseFormat(s)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for xml")
+	}
+}
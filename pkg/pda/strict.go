@@ -0,0 +1,40 @@
+package pda
+
+import "errors"
+
+// ErrMaxSeedLengthExceeded mirrors PubkeyError::MaxSeedLengthExceeded, which
+// the Solana runtime returns for both too many seeds and an oversized seed.
+// Only strict mode returns it.
+var ErrMaxSeedLengthExceeded = errors.New("length of the seed is too long for address generation")
+
+// WithStrict makes derivations mirror the Solana runtime's
+// create_program_address / find_program_address exactly:
+//
+//   - the marker, MaxSeeds and MaxSeedLength are the Solana values
+//   - the bump search covers 255 down to 1; the runtime never tries bump 0
+//   - too many seeds and an oversized seed both fail with
+//     ErrMaxSeedLengthExceeded when creating an address
+//   - when finding an address, invalid seeds make the search come up empty
+//     (ErrNoViableBump), just as find_program_address returns no result
+//
+// WithStrict overrides earlier marker, limit and bump range options; options
+// given after it still apply.
+func WithStrict() Option {
+	return func(c *config) {
+		c.strict = true
+		c.marker = pdaMarkerBytes
+		c.maxSeeds = MaxSeeds
+		c.maxSeedLength = MaxSeedLength
+		c.bumpStart = 255
+		c.bumpEnd = 1
+	}
+}
+
+// strictSeedError is the runtime's answer to invalid seeds: create fails
+// with MaxSeedLengthExceeded, find (reserved > 0) finds nothing
+func strictSeedError(reserved int) error {
+	if reserved > 0 {
+		return ErrNoViableBump{}
+	}
+	return ErrMaxSeedLengthExceeded
+}
@@ -0,0 +1,99 @@
+package pda
+
+import (
+	"errors"
+	"testing"
+)
+
+// Vectors from the create_program_address tests in the Solana Rust SDK
+func TestStrict_RustVectors(t *testing.T) {
+	program := MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111")
+	publicKey := MustNewAddress("SeedPubey1111111111111111111111111111111111")
+
+	tests := []struct {
+		seeds [][]byte
+		want  string
+	}{
+		{[][]byte{[]byte(""), {1}}, "BwqrghZA2htAcqq8dzP1WDAhTXYTYWj7CHxF5j7TDBAe"},
+		{[][]byte{[]byte("☉"), {0}}, "13yWmRpaTR4r5nAktwLqMpRNr28tnVUZw26rTvPSSB19"},
+		{[][]byte{[]byte("Talking"), []byte("Squirrels")}, "2fnQrngrQT4SeLcdToJAD96phoEjNL2man2kfRLCASVk"},
+		{[][]byte{publicKey[:], {1}}, "976ymqVnfE32QFe6NfGDctSvVa36LWnvYxhU6G2232YL"},
+	}
+
+	for _, tt := range tests {
+		for _, opts := range [][]Option{nil, {WithStrict()}} {
+			got, err := CreateProgramDerivedAddress(ProgramDerivedAddressInput{
+				ProgramAddress: program,
+				Seeds:          tt.seeds,
+			}, opts...)
+			if err != nil {
+				t.Errorf("%q: CreateProgramDerivedAddress failed: %v", tt.seeds, err)
+				continue
+			}
+			if got.String() != tt.want {
+				t.Errorf("%q: got %s, want %s", tt.seeds, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestStrict_MaxSeedLengthExceeded(t *testing.T) {
+	program := MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111")
+
+	exceededSeed := make([]byte, MaxSeedLength+1)
+	for i := range exceededSeed {
+		exceededSeed[i] = 127
+	}
+	exceededSeeds := make([][]byte, MaxSeeds+1)
+	maxSeeds := make([][]byte, MaxSeeds)
+	for i := range exceededSeeds {
+		exceededSeeds[i] = []byte{byte(i + 1)}
+	}
+	copy(maxSeeds, exceededSeeds)
+
+	for _, seeds := range [][][]byte{{exceededSeed}, {[]byte("short_seed"), exceededSeed}, exceededSeeds} {
+		_, err := CreateProgramDerivedAddress(ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds}, WithStrict())
+		if !errors.Is(err, ErrMaxSeedLengthExceeded) {
+			t.Errorf("expected ErrMaxSeedLengthExceeded, got: %v", err)
+		}
+	}
+
+	for _, seeds := range [][][]byte{{make([]byte, MaxSeedLength)}, maxSeeds} {
+		if _, err := CreateProgramDerivedAddress(ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds}, WithStrict()); err != nil {
+			t.Errorf("expected seeds at the limit to succeed, got: %v", err)
+		}
+	}
+
+	// find_program_address comes up empty instead of reporting the length
+	_, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{ProgramAddress: program, Seeds: [][]byte{exceededSeed}}, WithStrict())
+
+	var noBumpErr ErrNoViableBump
+	if !errors.As(err, &noBumpErr) {
+		t.Errorf("expected ErrNoViableBump, got: %v", err)
+	}
+}
+
+func TestStrict_NeverTriesBumpZero(t *testing.T) {
+	program := MustNewAddress("BPFLoaderUpgradeab1e11111111111111111111111")
+
+	bumps, err := AllValidBumps(ProgramDerivedAddressInput{
+		ProgramAddress: program,
+		Seeds:          [][]byte{[]byte("strict")},
+	}, WithStrict())
+	if err != nil {
+		t.Fatalf("AllValidBumps failed: %v", err)
+	}
+
+	if bumps[len(bumps)-1] == 0 {
+		t.Error("strict mode must not consider bump 0")
+	}
+
+	// Options after WithStrict still apply
+	_, err = GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: program,
+		Seeds:          [][]byte{[]byte("strict")},
+	}, WithStrict(), WithMarker([]byte("Fork")))
+	if err != nil {
+		t.Errorf("expected later options to apply, got: %v", err)
+	}
+}
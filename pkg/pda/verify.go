@@ -0,0 +1,65 @@
+package pda
+
+import (
+	"errors"
+	"fmt"
+)
+
+// VerifyPDA recomputes the derivation for seeds + bump under program and
+// reports whether it produces address. A bump that lands on the curve is
+// reported as a mismatch rather than an error; seeds that exceed the limits
+// are returned as errors.
+func VerifyPDA(address Address, program Address, seeds [][]byte, bump uint8, opts ...Option) (bool, error) {
+	cfg := newConfig(opts)
+
+	// Validate seeds (need room for bump seed)
+	if err := validateSeeds(seeds, 1, cfg); err != nil {
+		return false, err
+	}
+
+	programIdBytes := [32]byte(program)
+
+	seedsWithBump := make([][]byte, len(seeds)+1)
+	copy(seedsWithBump, seeds)
+	seedsWithBump[len(seeds)] = []byte{bump}
+
+	got, err := createProgramAddress(&programIdBytes, seedsWithBump, cfg)
+	if errors.Is(err, ErrPointOnCurve) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return Address(got) == address, nil
+}
+
+// ErrNonCanonicalBump reports a bump that derives a valid PDA but is not
+// the canonical (highest valid) bump for its seeds
+type ErrNonCanonicalBump struct {
+	Bump      uint8
+	Canonical uint8
+}
+
+func (e ErrNonCanonicalBump) Error() string {
+	return fmt.Sprintf("bump %d is not the canonical bump %d", e.Bump, e.Canonical)
+}
+
+func (e ErrNonCanonicalBump) Code() ErrorCode {
+	return CodeNonCanonical
+}
+
+// CheckCanonicalBump returns ErrNonCanonicalBump unless bump is the
+// canonical bump for seeds under program, the one FindBump returns.
+// Programs that store a bump should only accept the canonical one, or the
+// same seeds can address several accounts.
+func CheckCanonicalBump(program Address, seeds [][]byte, bump uint8, opts ...Option) error {
+	canonical, err := FindBump(program, seeds, opts...)
+	if err != nil {
+		return err
+	}
+	if bump != canonical {
+		return ErrNonCanonicalBump{Bump: bump, Canonical: canonical}
+	}
+	return nil
+}
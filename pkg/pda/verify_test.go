@@ -0,0 +1,81 @@
+package pda
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyPDA(t *testing.T) {
+	programAddr, err := NewAddress("11111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("failed to create program address: %v", err)
+	}
+
+	seeds := [][]byte{[]byte("verify")}
+	pda, err := GetProgramDerivedAddress(ProgramDerivedAddressInput{
+		ProgramAddress: programAddr,
+		Seeds:          seeds,
+	})
+	if err != nil {
+		t.Fatalf("GetProgramDerivedAddress failed: %v", err)
+	}
+
+	ok, err := VerifyPDA(pda.Address, programAddr, seeds, pda.Bump)
+	if err != nil {
+		t.Fatalf("VerifyPDA failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected derived PDA to verify")
+	}
+
+	ok, err = VerifyPDA(pda.Address, programAddr, [][]byte{[]byte("other")}, pda.Bump)
+	if err != nil {
+		t.Fatalf("VerifyPDA failed: %v", err)
+	}
+	if ok {
+		t.Error("expected different seeds not to verify")
+	}
+
+	// Bumps above the canonical one land on the curve and never verify
+	if pda.Bump < 255 {
+		ok, err = VerifyPDA(pda.Address, programAddr, seeds, pda.Bump+1)
+		if err != nil {
+			t.Fatalf("VerifyPDA failed: %v", err)
+		}
+		if ok {
+			t.Error("expected wrong bump not to verify")
+		}
+	}
+}
+
+func TestVerifyPDA_SeedTooLong(t *testing.T) {
+	_, err := VerifyPDA(Address{}, Address{}, [][]byte{make([]byte, MaxSeedLength+1)}, 255)
+
+	var seedTooLongErr ErrSeedTooLong
+	if !errors.As(err, &seedTooLongErr) {
+		t.Errorf("expected ErrSeedTooLong, got: %v", err)
+	}
+}
+
+func TestCheckCanonicalBump(t *testing.T) {
+	program := MustNewAddress("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	seeds := [][]byte{[]byte("stats-9")}
+
+	valid, err := AllValidBumps(ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds})
+	if err != nil {
+		t.Fatalf("AllValidBumps failed: %v", err)
+	}
+
+	if err := CheckCanonicalBump(program, seeds, valid[0]); err != nil {
+		t.Errorf("canonical bump %d rejected: %v", valid[0], err)
+	}
+
+	err = CheckCanonicalBump(program, seeds, valid[1])
+	var nonCanonical ErrNonCanonicalBump
+	if !errors.As(err, &nonCanonical) || nonCanonical.Canonical != valid[0] {
+		t.Fatalf("expected ErrNonCanonicalBump, got: %v", err)
+	}
+	if ErrorCodeOf(err) != CodeNonCanonical {
+		t.Errorf("expected %s, got %s", CodeNonCanonical, ErrorCodeOf(err))
+	}
+}
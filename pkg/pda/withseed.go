@@ -0,0 +1,30 @@
+package pda
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+// ErrIllegalOwner is returned by CreateWithSeed when the owner ends with
+// the PDA marker, which would let the result collide with a PDA preimage
+var ErrIllegalOwner = errors.New("provided owner is not allowed")
+
+// CreateWithSeed derives sha256(base || seed || owner), the address of an
+// account created with SystemProgram.CreateAccountWithSeed, matching
+// Solana's Pubkey::create_with_seed. Unlike a PDA the result may be on the
+// curve. The seed is limited to MaxSeedLength bytes.
+func CreateWithSeed(base Address, seed string, owner Address) (Address, error) {
+	if len(seed) > MaxSeedLength {
+		return Address{}, ErrMaxSeedLengthExceeded
+	}
+	if bytes.HasSuffix(owner[:], pdaMarkerBytes) {
+		return Address{}, ErrIllegalOwner
+	}
+
+	h := sha256.New()
+	h.Write(base[:])
+	h.Write([]byte(seed))
+	h.Write(owner[:])
+	return Address(h.Sum(nil)), nil
+}
@@ -0,0 +1,39 @@
+package pda
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCreateWithSeed(t *testing.T) {
+	// Vector from the Solana SDK's Pubkey::create_with_seed tests
+	got, err := CreateWithSeed(Address{}, "limber chicken: 4/45", Address{})
+	if err != nil {
+		t.Fatalf("CreateWithSeed failed: %v", err)
+	}
+	if want := MustNewAddress("9h1HyLCW5dZnBVap8C5egQ9Z6pHyjsh5MNy83iPqqRuq"); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if _, err := CreateWithSeed(Address{}, strings.Repeat("x", MaxSeedLength), Address{}); err != nil {
+		t.Errorf("expected a %d-byte seed to be accepted, got: %v", MaxSeedLength, err)
+	}
+}
+
+func TestCreateWithSeed_Errors(t *testing.T) {
+	_, err := CreateWithSeed(Address{}, strings.Repeat("x", MaxSeedLength+1), Address{})
+	if !errors.Is(err, ErrMaxSeedLengthExceeded) {
+		t.Errorf("expected ErrMaxSeedLengthExceeded, got: %v", err)
+	}
+
+	var owner Address
+	copy(owner[32-len(pdaMarkerBytes):], pdaMarkerBytes)
+	_, err = CreateWithSeed(Address{}, "seed", owner)
+	if !errors.Is(err, ErrIllegalOwner) {
+		t.Errorf("expected ErrIllegalOwner, got: %v", err)
+	}
+	if ErrorCodeOf(err) != CodeIllegalOwner {
+		t.Errorf("expected %s, got %s", CodeIllegalOwner, ErrorCodeOf(err))
+	}
+}
@@ -0,0 +1,123 @@
+// Package wasmassets embeds the WASM bridge (main.wasm, built from
+// cmd/wasm) and the wasm_exec.js it needs, and serves them over HTTP, so
+// a Go web app can host the bridge itself:
+//
+//	mux.Handle("/pda/", http.StripPrefix("/pda", wasmassets.Handler()))
+//
+// The module is a build artifact and is not checked in: run
+//
+//	go generate raccoon-wasm/pkg/wasmassets
+//
+// before building, which also refreshes wasm_exec.js from the same Go
+// toolchain. Without it Module returns ErrNoModule and the handler answers
+// /main.wasm with a 500 that says so.
+package wasmassets
+
+//go:generate sh -c "GOOS=js GOARCH=wasm go build -trimpath -o assets/main.wasm ../../cmd/wasm && cp \"$(go env GOROOT)/lib/wasm/wasm_exec.js\" assets/"
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"time"
+)
+
+//go:embed assets
+var embedded embed.FS
+
+// FS returns the embedded assets: main.wasm (once generated) and
+// wasm_exec.js
+func FS() fs.FS {
+	sub, _ := fs.Sub(embedded, "assets")
+	return sub
+}
+
+// moduleName is the name of the generated module
+const moduleName = "main.wasm"
+
+// ErrNoModule is returned by Module when main.wasm was not generated
+var ErrNoModule = errors.New("wasmassets: main.wasm was not generated; run go generate raccoon-wasm/pkg/wasmassets")
+
+// Module returns the embedded module, or ErrNoModule if it was not
+// generated before building. Apps that serve the bridge can call it at
+// startup to fail early.
+func Module() ([]byte, error) {
+	return readModule(FS())
+}
+
+func readModule(files fs.FS) ([]byte, error) {
+	data, err := fs.ReadFile(files, moduleName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoModule
+	}
+	return data, err
+}
+
+// contentTypes are the MIME types of the assets; browsers only stream-compile
+// modules served as application/wasm
+var contentTypes = map[string]string{
+	".wasm": "application/wasm",
+	".js":   "text/javascript; charset=utf-8",
+}
+
+// asset is an embedded file ready to serve
+type asset struct {
+	data        []byte
+	etag        string
+	contentType string
+}
+
+// Handler serves the assets by name (/main.wasm, /wasm_exec.js) with their
+// MIME types, a content-hash ETag and Cache-Control: no-cache, so clients
+// keep their copy but revalidate it, getting a 304 until the assets
+// change. It answers GET and HEAD, including range requests. If main.wasm
+// was not generated, requests for it fail with a 500 carrying ErrNoModule
+// rather than a 404.
+func Handler() http.Handler {
+	return handler(FS())
+}
+
+func handler(files fs.FS) http.Handler {
+	assets := map[string]asset{}
+	entries, _ := fs.ReadDir(files, ".")
+	for _, e := range entries {
+		data, err := fs.ReadFile(files, e.Name())
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		assets["/"+e.Name()] = asset{
+			data:        data,
+			etag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+			contentType: contentTypes[path.Ext(e.Name())],
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		a, ok := assets[r.URL.Path]
+		if !ok && r.URL.Path == "/"+moduleName {
+			http.Error(w, ErrNoModule.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Set("Content-Type", a.contentType)
+		h.Set("ETag", a.etag)
+		h.Set("Cache-Control", "no-cache")
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(a.data))
+	})
+}
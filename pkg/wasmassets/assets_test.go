@@ -0,0 +1,88 @@
+package wasmassets
+
+import (
+	"bytes"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHandler(t *testing.T) {
+	h := Handler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/wasm_exec.js", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/javascript; charset=utf-8" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" || rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("expected ETag and Cache-Control headers, got %v", rec.Header())
+	}
+
+	// A client holding the current copy gets a 304
+	req := httptest.NewRequest("GET", "/wasm_exec.js", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for a matching ETag, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/missing.js", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/wasm_exec.js", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Module(t *testing.T) {
+	if _, err := fs.Stat(FS(), "main.wasm"); err != nil {
+		t.Skip("main.wasm not generated; run go generate")
+	}
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/main.wasm", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/wasm" {
+		t.Errorf("expected main.wasm as application/wasm, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestHandler_NoModule(t *testing.T) {
+	files := fstest.MapFS{"wasm_exec.js": {Data: []byte("// loader")}}
+
+	rec := httptest.NewRecorder()
+	handler(files).ServeHTTP(rec, httptest.NewRequest("GET", "/main.wasm", nil))
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "go generate") {
+		t.Errorf("expected a 500 naming go generate, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	if _, err := readModule(files); err != ErrNoModule {
+		t.Errorf("expected ErrNoModule, got: %v", err)
+	}
+}
+
+func TestModule(t *testing.T) {
+	data, err := Module()
+	if _, statErr := fs.Stat(FS(), "main.wasm"); statErr != nil {
+		if err != ErrNoModule {
+			t.Errorf("expected ErrNoModule without main.wasm, got: %v", err)
+		}
+		return
+	}
+	if err != nil || !bytes.HasPrefix(data, []byte("\x00asm")) {
+		t.Errorf("expected the module, got %d bytes, %v", len(data), err)
+	}
+}
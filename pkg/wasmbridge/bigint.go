@@ -0,0 +1,15 @@
+//go:build js && wasm && !tinygo
+
+package wasmbridge
+
+import "syscall/js"
+
+// isBigInt reports whether val is a BigInt, the one JS type syscall/js
+// has no Type for: val.Type() panics on it
+func isBigInt(val js.Value) (big bool) {
+	defer func() {
+		big = recover() != nil
+	}()
+	val.Type()
+	return false
+}
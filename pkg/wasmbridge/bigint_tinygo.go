@@ -0,0 +1,12 @@
+//go:build js && wasm && tinygo
+
+package wasmbridge
+
+import "syscall/js"
+
+// isBigInt reports whether val is a BigInt. TinyGo's wasm_exec.js tags
+// BigInts as objects instead of making val.Type() panic, so they are told
+// apart by boxing: Object(val) is a BigInt instance only for BigInts.
+func isBigInt(val js.Value) bool {
+	return jsObject.Invoke(val).InstanceOf(jsBigInt)
+}
@@ -0,0 +1,1497 @@
+//go:build js && wasm
+
+// Package wasmbridge exposes the pda package to JavaScript through
+// syscall/js. cmd/wasm builds it as a standalone module; other Go wasm
+// applications can embed the same functions in their own module instead
+// of loading a second one:
+//
+//	wasmbridge.Register("solanaPda")
+//	defer wasmbridge.Shutdown()
+//
+// The functions are methods of a single namespace object; see Register.
+package wasmbridge
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/mr-tron/base58"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// --- Helper to parse inputs safely ---
+
+var (
+	jsObject     = js.Global().Get("Object")
+	jsArray      = js.Global().Get("Array")
+	jsUint8Array = js.Global().Get("Uint8Array")
+	jsString     = js.Global().Get("String")
+	jsError      = js.Global().Get("Error")
+	jsArrayBuf   = js.Global().Get("ArrayBuffer")
+	jsSharedBuf  = js.Global().Get("SharedArrayBuffer") // undefined without cross-origin isolation
+	jsReflect    = js.Global().Get("Reflect")
+	jsPromise    = js.Global().Get("Promise")
+	jsBigInt     = js.Global().Get("BigInt")
+)
+
+// get returns the property name of an object passed in from JS. Unlike
+// val.Get, which lets an exception thrown by a getter unwind straight
+// through the Go runtime, Reflect.get turns it into a panic the bridge
+// functions recover from.
+func get(val js.Value, name string) js.Value {
+	return jsReflect.Call("get", val, name)
+}
+
+// Scratch reused across calls to keep per-call garbage down. JS calls into
+// the module one at a time, and FindPDA does not retain its seeds, so
+// reusing them between calls is safe.
+var (
+	seedBuf []byte
+	seeds   [][]byte
+)
+
+// seedEncoding selects how String seeds are turned into bytes
+type seedEncoding int
+
+const (
+	encodingUTF8     seedEncoding = iota // the string's UTF-8 bytes
+	encodingHex                          // hex digits
+	encodingBase64                       // standard, padded base64
+	encodingPrefixed                     // "hex:" or "base64:" prefix, else UTF-8
+)
+
+func parseSeedEncoding(s string) (seedEncoding, error) {
+	switch s {
+	case "", "utf8", "utf-8":
+		return encodingUTF8, nil
+	case "hex":
+		return encodingHex, nil
+	case "base64":
+		return encodingBase64, nil
+	case "prefixed":
+		return encodingPrefixed, nil
+	}
+	return 0, fmt.Errorf("unknown seed encoding %q (want utf8, hex, base64 or prefixed)", s)
+}
+
+// callOptions holds the options object accepted by the bridge functions:
+// how seeds are read from JS and what results include
+type callOptions struct {
+	form            pda.NormalizationForm
+	encoding        seedEncoding
+	addressEncoding string // "base58" (default), "hex" or "base64"
+	includeBytes    bool   // add addressBytes (a Uint8Array) to results
+	canonicalBump   bool   // create: reject a final bump seed that is not canonical
+	signal          js.Value
+	output          js.Value // batch: Uint8Array to pack results into
+}
+
+// formatAddress encodes addr as the options select
+func (o callOptions) formatAddress(addr pda.Address) string {
+	switch o.addressEncoding {
+	case "hex":
+		return addr.ToHex()
+	case "base64":
+		return addr.ToBase64()
+	}
+	return addr.String()
+}
+
+// appendString appends the bytes of a String seed to seedBuf. Only UTF-8
+// seeds are normalized; changed reports whether that altered their bytes.
+func appendString(s string, opts callOptions) (changed bool, err error) {
+	encoding := opts.encoding
+	if encoding == encodingPrefixed {
+		encoding = encodingUTF8
+		if rest, ok := strings.CutPrefix(s, "hex:"); ok {
+			s, encoding = rest, encodingHex
+		} else if rest, ok := strings.CutPrefix(s, "base64:"); ok {
+			s, encoding = rest, encodingBase64
+		}
+	}
+
+	switch encoding {
+	case encodingHex:
+		seedBuf, err = hex.AppendDecode(seedBuf, []byte(s))
+	case encodingBase64:
+		seedBuf, err = base64.StdEncoding.AppendDecode(seedBuf, []byte(s))
+	default:
+		if opts.form == pda.NoNormalization {
+			seedBuf = append(seedBuf, s...)
+		} else {
+			var b []byte
+			b, changed = pda.NormalizeSeed(s, opts.form)
+			seedBuf = append(seedBuf, b...)
+		}
+	}
+	return changed, err
+}
+
+// appendSeed appends the bytes of a JS String or Uint8Array to seedBuf and
+// returns them. Strings are decoded as opts selects, and changed reports
+// whether normalization altered their bytes.
+func appendSeed(val js.Value, opts callOptions) (b []byte, changed bool, err error) {
+	start := len(seedBuf)
+
+	if isBigInt(val) {
+		return nil, false, errors.New(`seed is a BigInt; pass integers as {type: "u64", value}`)
+	}
+
+	if val.Type() == js.TypeString {
+		changed, err = appendString(val.String(), opts)
+		if err != nil {
+			seedBuf = seedBuf[:start]
+			return nil, false, err
+		}
+		return seedBuf[start:], changed, nil
+	}
+
+	if bytes, ok, err := byteView(val); err != nil {
+		return nil, false, err
+	} else if ok {
+		seedBuf = slices.Grow(seedBuf, bytes.Length())[:start+bytes.Length()]
+		js.CopyBytesToGo(seedBuf[start:], bytes)
+		return seedBuf[start:], false, nil
+	}
+
+	if val.InstanceOf(jsArray) {
+		if err := appendByteArray(val, 0); err != nil {
+			seedBuf = seedBuf[:start]
+			return nil, false, err
+		}
+		return seedBuf[start:], false, nil
+	}
+
+	if val.Type() == js.TypeObject && !get(val, "type").IsUndefined() {
+		if err := appendTypedSeed(val); err != nil {
+			seedBuf = seedBuf[:start]
+			return nil, false, err
+		}
+		return seedBuf[start:], false, nil
+	}
+
+	return nil, false, errors.New("seed must be String, Uint8Array (or another ArrayBuffer view), ArrayBuffer, Array of bytes or {type, value}")
+}
+
+// byteView returns a Uint8Array over the bytes of an ArrayBuffer,
+// SharedArrayBuffer, TypedArray or DataView, honouring the view's
+// byteOffset and byteLength. Other TypedArrays contribute their raw bytes
+// in platform (little-endian) order, not one byte per element. Views of a
+// detached (transferred) buffer are an error rather than an empty seed.
+func byteView(val js.Value) (js.Value, bool, error) {
+	if val.Type() != js.TypeObject {
+		return js.Value{}, false, nil
+	}
+
+	// Detached buffers have no bytes, so only empty ones need checking
+	if val.InstanceOf(jsUint8Array) {
+		if val.Length() == 0 && detached(val.Get("buffer")) {
+			return js.Value{}, false, errDetached
+		}
+		return val, true, nil
+	}
+	if val.InstanceOf(jsArrayBuf) || (jsSharedBuf.Type() == js.TypeFunction && val.InstanceOf(jsSharedBuf)) {
+		if val.Get("byteLength").Int() == 0 && detached(val) {
+			return js.Value{}, false, errDetached
+		}
+		return jsUint8Array.New(val), true, nil
+	}
+	if jsArrayBuf.Call("isView", val).Bool() {
+		// A DataView's getters throw once its buffer is detached, so the
+		// check is made on the buffer
+		buf := get(val, "buffer")
+		if buf.Get("byteLength").Int() == 0 && detached(buf) {
+			return js.Value{}, false, errDetached
+		}
+		return jsUint8Array.New(buf, get(val, "byteOffset"), get(val, "byteLength")), true, nil
+	}
+	return js.Value{}, false, nil
+}
+
+var errDetached = errors.New("buffer is detached (it was transferred)")
+
+// detached reports whether the ArrayBuffer buf has been detached, e.g. by
+// being transferred to a worker
+func detached(buf js.Value) (d bool) {
+	if v := buf.Get("detached"); v.Type() == js.TypeBoolean {
+		return v.Bool()
+	}
+	// Engines without ArrayBuffer.prototype.detached refuse to construct a
+	// view over a detached buffer
+	defer func() {
+		if recover() != nil {
+			d = true
+		}
+	}()
+	jsUint8Array.New(buf)
+	return false
+}
+
+// appendTypedSeed appends a {type, value} seed such as {type: "u64",
+// value: "42"} to seedBuf, encoded as pda.ParseTypedSeed does. The value
+// may be a String, Number or BigInt; large integers should be passed as
+// String or BigInt, since Numbers lose precision above 2^53.
+//
+// TypeScript:
+//
+//	type TypedSeed = { type: TypedSeedKind; value: string | number | bigint };
+func appendTypedSeed(val js.Value) error {
+	kind := get(val, "type")
+	if kind.Type() != js.TypeString {
+		return errors.New("seed type must be a String")
+	}
+
+	// String() converts Numbers and BigInts without going through float64
+	value := get(val, "value")
+	if value.IsUndefined() || value.IsNull() {
+		return fmt.Errorf("%s seed has no value", kind.String())
+	}
+
+	b, err := pda.ParseTypedSeed(kind.String(), jsString.Invoke(value).String())
+	if err != nil {
+		return fmt.Errorf("%s seed: %v", kind.String(), err)
+	}
+	seedBuf = append(seedBuf, b...)
+	return nil
+}
+
+// maxSeedNesting bounds how deep appendByteArray flattens nested Arrays,
+// which also stops it on self-referencing arrays
+const maxSeedNesting = 16
+
+var errSeedTooDeep = fmt.Errorf("arrays nested more than %d deep", maxSeedNesting)
+
+// appendByteArray appends a JS Array of numbers, each an integer from 0 to
+// 255, to seedBuf. Nested Arrays are flattened in order.
+//
+// TypeScript:
+//
+//	type NestedBytes = (number | NestedBytes)[];
+func appendByteArray(val js.Value, depth int) error {
+	if depth >= maxSeedNesting {
+		return errSeedTooDeep
+	}
+
+	for i, n := 0, val.Length(); i < n; i++ {
+		elem := val.Index(i)
+		if elem.InstanceOf(jsArray) {
+			if err := appendByteArray(elem, depth+1); err == errSeedTooDeep {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+			continue
+		}
+		if isBigInt(elem) {
+			return fmt.Errorf("element %d is a BigInt, not a number", i)
+		}
+		if elem.Type() != js.TypeNumber {
+			return fmt.Errorf("element %d is %s, not a number", i, elem.Type())
+		}
+		f := elem.Float()
+		if f != math.Trunc(f) || f < 0 || f > 255 {
+			return fmt.Errorf("element %d (%v) is not a byte value 0-255", i, f)
+		}
+		seedBuf = append(seedBuf, byte(f))
+	}
+	return nil
+}
+
+// readAddress reads an address argument given as a base58 String or a
+// 32-byte Uint8Array
+//
+// TypeScript:
+//
+//	type AddressInput = string | Uint8Array;
+func readAddress(val js.Value) (pda.Address, error) {
+	if isBigInt(val) {
+		return pda.Address{}, errors.New("address must be String or Uint8Array")
+	}
+	if val.Type() == js.TypeString {
+		return pda.NewAddress(val.String())
+	}
+
+	if val.InstanceOf(jsUint8Array) {
+		var addr pda.Address
+		if n := val.Length(); n != len(addr) {
+			return pda.Address{}, pda.ErrInvalidAddressLength{Length: n}
+		}
+		js.CopyBytesToGo(addr[:], val)
+		return addr, nil
+	}
+
+	return pda.Address{}, errors.New("address must be String or Uint8Array")
+}
+
+// programIDArg returns the program argument as base58 for FindPDA, which
+// decodes (and caches) program IDs itself
+func programIDArg(val js.Value) (string, error) {
+	if !isBigInt(val) && val.Type() == js.TypeString {
+		return val.String(), nil
+	}
+	addr, err := readAddress(val)
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
+
+// parseOptions reads the optional options argument at args[i]. Functions
+// ignore options that do not apply to them, so new options can be added
+// without changing any positional arguments. An already aborted
+// abortSignal fails the call with PDA_ERR_CANCELED.
+//
+// TypeScript:
+//
+//	type PdaOptions = {
+//	  normalize?: "none" | "NFC" | "NFKC";
+//	  // How String seeds become bytes; "prefixed" decodes "hex:..." and
+//	  // "base64:..." seeds and treats other strings as UTF-8
+//	  seedEncoding?: "utf8" | "hex" | "base64" | "prefixed";
+//	  // Encoding of result addresses (default base58)
+//	  encoding?: "base58" | "hex" | "base64";
+//	  // Add addressBytes (the raw 32 bytes) to results
+//	  includeBytes?: boolean;
+//	  // create: fail with PDA_ERR_NON_CANONICAL_BUMP unless the last seed is
+//	  // the canonical bump
+//	  commitmentToCanonicalBump?: boolean;
+//	  // Given one, getProgramDerivedAddresses, deriveRange and grind run
+//	  // asynchronously and return a Promise, which an abort rejects
+//	  abortSignal?: AbortSignal;
+//	  // getProgramDerivedAddresses packs result i into output at i*33
+//	  // (32-byte address, then the bump) and returns only the failures
+//	  output?: SharedArrayBuffer | ArrayBuffer | ArrayBufferView;
+//	};
+//	type Abortable = PdaOptions & { abortSignal: AbortSignal };
+//	type PackedOptions = PdaOptions & { output: SharedArrayBuffer | ArrayBuffer | ArrayBufferView };
+func parseOptions(args []js.Value, i int) (opts callOptions, err error) {
+	if len(args) <= i || args[i].IsUndefined() || args[i].IsNull() {
+		return opts, nil
+	}
+	o := args[i]
+	if isBigInt(o) || o.Type() != js.TypeObject {
+		return opts, errors.New("options must be an object")
+	}
+
+	if normalize := get(o, "normalize"); !normalize.IsUndefined() {
+		if opts.form, err = pda.ParseNormalizationForm(normalize.String()); err != nil {
+			return opts, err
+		}
+	}
+	if encoding := get(o, "seedEncoding"); !encoding.IsUndefined() {
+		if opts.encoding, err = parseSeedEncoding(encoding.String()); err != nil {
+			return opts, err
+		}
+	}
+	if encoding := get(o, "encoding"); !encoding.IsUndefined() {
+		switch opts.addressEncoding = encoding.String(); opts.addressEncoding {
+		case "base58", "hex", "base64":
+		default:
+			return opts, fmt.Errorf("unknown address encoding %q (want base58, hex or base64)", opts.addressEncoding)
+		}
+	}
+	opts.includeBytes = get(o, "includeBytes").Truthy()
+	opts.canonicalBump = get(o, "commitmentToCanonicalBump").Truthy()
+
+	if output := get(o, "output"); !output.IsUndefined() {
+		view, ok, err := byteView(output)
+		if err != nil {
+			return opts, fmt.Errorf("output %w", err)
+		}
+		if !ok {
+			return opts, errors.New("output must be a SharedArrayBuffer, ArrayBuffer or view of one")
+		}
+		opts.output = view
+	}
+
+	if signal := get(o, "abortSignal"); !signal.IsUndefined() && !signal.IsNull() {
+		opts.signal = signal
+		if err := opts.aborted(); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// aborted returns a context.Canceled error, carrying the abort reason, if
+// the call's abortSignal has fired
+func (o callOptions) aborted() error {
+	if o.signal.IsUndefined() || !get(o.signal, "aborted").Truthy() {
+		return nil
+	}
+	return fmt.Errorf("aborted (%s): %w", jsString.Invoke(get(o.signal, "reason")).String(), context.Canceled)
+}
+
+// --- WASM Bridge ---
+
+// setAddress sets result.address to the address, base58 unless the
+// options select another encoding, and, if requested, result.addressBytes
+// to its 32 raw bytes
+//
+// TypeScript:
+//
+//	type AddressResult = { address: string; addressBytes?: Uint8Array };
+func setAddress(result js.Value, addr pda.Address, opts callOptions) {
+	result.Set("address", opts.formatAddress(addr))
+	if opts.includeBytes {
+		b := jsUint8Array.New(len(addr))
+		js.CopyBytesToJS(b, addr[:])
+		result.Set("addressBytes", b)
+	}
+}
+
+// The results are built directly as JS objects rather than through
+// map[string]interface{} and js.ValueOf, which allocates the map and walks
+// it reflectively on every call.
+
+// Failures are reported to JS as Error instances carrying a code from the
+// shared pda.ErrorCode set and, for errors about one seed, its seedIndex.
+// Go callbacks cannot throw, so the functions return the Error and the
+// wrapper built by throwingWrapper throws it.
+
+// seedError ties a failure to read a seed to the seed's index
+type seedError struct {
+	Index int
+	Err   error
+}
+
+func (e seedError) Error() string { return fmt.Sprintf("seed %d: %v", e.Index, e.Err) }
+func (e seedError) Unwrap() error { return e.Err }
+
+// newError builds the JS Error for err with the given code
+//
+// TypeScript:
+//
+//	interface PdaError extends Error {
+//	  code: PdaErrorCode;
+//	  // Set when the error concerns a single seed
+//	  seedIndex?: number;
+//	  // The request or range index of a failed item in a batch result
+//	  index?: RangeIndex;
+//	}
+func newError(err error, code pda.ErrorCode) js.Value {
+	e := jsError.New(err.Error())
+	e.Set("code", string(code))
+
+	var se seedError
+	var tooLong pda.ErrSeedTooLong
+	if errors.As(err, &se) {
+		e.Set("seedIndex", se.Index)
+	} else if errors.As(err, &tooLong) {
+		e.Set("seedIndex", tooLong.Index)
+	}
+	return e
+}
+
+// errorResult converts err into the Error reported to JS
+func errorResult(err error) js.Value {
+	return newError(err, pda.ErrorCodeOf(err))
+}
+
+// inputError reports a failure to read the arguments: package errors keep
+// their code, anything else is a malformed argument
+func inputError(err error) js.Value {
+	code := pda.ErrorCodeOf(err)
+	if code == pda.CodeUnknown {
+		code = pda.CodeInvalidArgument
+	}
+	return newError(err, code)
+}
+
+// argumentError reports a malformed call from JS
+func argumentError(msg string) js.Value {
+	return newError(errors.New(msg), pda.CodeInvalidArgument)
+}
+
+// throwingWrapper returns a JS function that wraps a bridge function and
+// throws the Error it returns. It is nil where the host forbids compiling
+// code from strings (e.g. Cloudflare Workers); the bridge functions then
+// return the Error instead of throwing it.
+func throwingWrapper() (wrap js.Value) {
+	defer func() {
+		if recover() != nil {
+			wrap = js.Null()
+		}
+	}()
+	return js.Global().Get("Function").New("fn", `return function () {
+		const result = fn.apply(this, arguments);
+		if (result instanceof Error) throw result;
+		return result;
+	};`)
+}
+
+// Calls given an abortSignal run asynchronously: they return a Promise and
+// do the work on a goroutine, which steps aside every yieldInterval so the
+// JS event loop can run and deliver the abort. The goroutine observes it as
+// the cancellation of its context.
+
+// yieldInterval is how long async work runs between yields to JS
+const yieldInterval = 50 * time.Millisecond
+
+// yieldToJS blocks the calling goroutine briefly. With no goroutine
+// runnable, the Go scheduler hands control back to the JS event loop until
+// the timer fires, so pending events are handled in the meantime.
+func yieldToJS() {
+	time.Sleep(time.Millisecond)
+}
+
+// work is the body of a call that may run asynchronously. It returns the
+// result or an Error; yield is nil when the call runs synchronously.
+type work func(ctx context.Context, yield func()) js.Value
+
+// stopCtx is canceled by Shutdown, and with it every call running
+// asynchronously; inflight counts those calls
+var (
+	stopCtx, stopAll = context.WithCancelCause(context.Background())
+	inflight         sync.WaitGroup
+)
+
+// errStopped is the cause of the calls canceled by Shutdown
+var errStopped = fmt.Errorf("bridge stopped: %w", context.Canceled)
+
+// run calls w directly, or, if the call has an abortSignal, on a goroutine
+// whose context the signal cancels, returning a Promise for its result
+func run(opts callOptions, w work) js.Value {
+	if opts.signal.IsUndefined() {
+		return w(context.Background(), nil)
+	}
+
+	ctx, cancel := context.WithCancelCause(stopCtx)
+	onAbort := js.FuncOf(func(js.Value, []js.Value) interface{} {
+		cancel(opts.aborted())
+		return nil
+	})
+	opts.signal.Call("addEventListener", "abort", onAbort)
+
+	executor := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		resolve, reject := args[0], args[1]
+		inflight.Add(1)
+		go func() {
+			defer inflight.Done()
+			defer func() {
+				opts.signal.Call("removeEventListener", "abort", onAbort)
+				onAbort.Release()
+				cancel(nil)
+			}()
+			defer func() {
+				if r := recover(); r != nil {
+					reject.Invoke(argumentError(fmt.Sprint(r)))
+				}
+			}()
+
+			if result := w(ctx, yieldToJS); result.InstanceOf(jsError) {
+				reject.Invoke(result)
+			} else {
+				resolve.Invoke(result)
+			}
+		}()
+		return nil
+	})
+	defer executor.Release()
+	return jsPromise.New(executor)
+}
+
+// paced returns the progress callback and interval for a search run by
+// work: fn (which may be nil) is still called every interval, and the
+// search yields to JS every yieldInterval
+func paced(yield func(), fn pda.ProgressFunc, interval time.Duration) (pda.ProgressFunc, time.Duration) {
+	if yield == nil {
+		return fn, interval
+	}
+	if fn == nil {
+		return func(uint64, float64) { yield() }, yieldInterval
+	}
+
+	last := time.Now()
+	return func(done uint64, rate float64) {
+		if time.Since(last) >= interval {
+			last = time.Now()
+			fn(done, rate)
+		}
+		yield()
+	}, min(interval, yieldInterval)
+}
+
+// readSeeds converts a JS Array of seeds into the scratch seeds slice.
+// normalized is an Array of the indices normalization changed, or
+// undefined if none.
+//
+// TypeScript:
+//
+//	// Number arrays must hold integers 0-255 and may be nested; views
+//	// (TypedArrays, DataView) contribute their raw bytes
+//	type SeedInput = string | ArrayBuffer | ArrayBufferView | NestedBytes | TypedSeed;
+func readSeeds(seedsJS js.Value, opts callOptions) (normalized js.Value, err error) {
+	if !seedsJS.InstanceOf(jsArray) {
+		return js.Undefined(), errors.New("seeds must be an Array")
+	}
+	length := seedsJS.Length()
+	seedBuf = seedBuf[:0]
+	seeds = slices.Grow(seeds[:0], length)
+
+	for i := 0; i < length; i++ {
+		b, changed, err := appendSeed(seedsJS.Index(i), opts)
+		if err != nil {
+			return js.Undefined(), seedError{Index: i, Err: err}
+		}
+		if changed {
+			if normalized.IsUndefined() {
+				normalized = jsArray.New()
+			}
+			normalized.Call("push", i)
+		}
+		seeds = append(seeds, b)
+	}
+	return normalized, nil
+}
+
+// findJS derives the canonical PDA.
+// args: (programId, seedsArray, [options])
+//
+// TypeScript:
+//
+//	type PdaResult = AddressResult & { bump: number; normalized?: number[] };
+//	find(programId: AddressInput, seeds: SeedInput[], options?: PdaOptions): PdaResult;
+func findJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return argumentError("args: (programId, seedsArray, [options])")
+	}
+
+	opts, err := parseOptions(args, 2)
+	if err != nil {
+		return inputError(err)
+	}
+
+	progID, err := programIDArg(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+
+	// Convert JS Array to Go Slice of Bytes, reusing the scratch buffers
+	normalized, err := readSeeds(args[1], opts)
+	if err != nil {
+		return inputError(err)
+	}
+
+	addr, bump, err := pda.FindPDA(progID, seeds)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	result := jsObject.New()
+	if opts.includeBytes || opts.addressEncoding != "" {
+		raw, _ := pda.DecodeAddress(addr)
+		setAddress(result, raw, opts)
+	} else {
+		result.Set("address", addr)
+	}
+	result.Set("bump", bump)
+	if !normalized.IsUndefined() {
+		result.Set("normalized", normalized)
+	}
+	return result
+}
+
+// packedRecordSize is the size of a result packed into an output buffer:
+// the 32-byte address followed by the bump
+const packedRecordSize = len(pda.Address{}) + 1
+
+// getProgramDerivedAddressesJS derives a PDA for every {programId, seeds}
+// request in one call, returning {address, bump} or an Error per item, so
+// one bad request does not fail the others. With an abortSignal it returns
+// a Promise.
+//
+// Given an output buffer (ideally a SharedArrayBuffer), result i is
+// instead packed into it at i*33 and only the failures are returned, as an
+// Array of Errors carrying their request index; their records are zeroed.
+// This skips creating an object per result.
+// args: (requestsArray, [options])
+//
+// TypeScript:
+//
+//	type PdaBatchRequest = { programId: AddressInput; seeds: SeedInput[] };
+//	getProgramDerivedAddresses(requests: PdaBatchRequest[], options: PackedOptions & Abortable): Promise<PdaError[]>;
+//	getProgramDerivedAddresses(requests: PdaBatchRequest[], options: PackedOptions): PdaError[];
+//	getProgramDerivedAddresses(requests: PdaBatchRequest[], options: Abortable): Promise<(PdaResult | PdaError)[]>;
+//	getProgramDerivedAddresses(requests: PdaBatchRequest[], options?: PdaOptions): (PdaResult | PdaError)[];
+func getProgramDerivedAddressesJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || !args[0].InstanceOf(jsArray) {
+		return argumentError("args: (requestsArray, [options])")
+	}
+
+	opts, err := parseOptions(args, 1)
+	if err != nil {
+		return inputError(err)
+	}
+
+	requests := args[0]
+	n := requests.Length()
+
+	packed := !opts.output.IsUndefined()
+	if need := n * packedRecordSize; packed && opts.output.Length() < need {
+		return argumentError(fmt.Sprintf("output holds %d bytes; %d results need %d", opts.output.Length(), n, need))
+	}
+	var results js.Value
+	if packed {
+		results = jsArray.New()
+	} else {
+		results = jsArray.New(n)
+	}
+	fail := func(i int, e js.Value) {
+		if packed {
+			e.Set("index", i)
+			results.Call("push", e)
+		} else {
+			results.SetIndex(i, e)
+		}
+	}
+
+	// Parse every request first, so the derivations run as one batch.
+	// readSeeds reuses the scratch buffers, so each request's seeds are
+	// copied out.
+	inputs := make([]pda.ProgramDerivedAddressInput, 0, n)
+	indices := make([]int, 0, n)
+	normalized := make([]js.Value, 0, n)
+	for i := 0; i < n; i++ {
+		req := requests.Index(i)
+		if isBigInt(req) || req.Type() != js.TypeObject || !get(req, "seeds").InstanceOf(jsArray) {
+			fail(i, argumentError("request must be {programId, seeds}"))
+			continue
+		}
+
+		program, err := readAddress(get(req, "programId"))
+		if err != nil {
+			fail(i, inputError(err))
+			continue
+		}
+		changed, err := readSeeds(get(req, "seeds"), opts)
+		if err != nil {
+			fail(i, inputError(err))
+			continue
+		}
+
+		own := make([][]byte, len(seeds))
+		buf := slices.Clone(seedBuf)
+		for j, s := range seeds {
+			own[j], buf = buf[:len(s):len(s)], buf[len(s):]
+		}
+		inputs = append(inputs, pda.ProgramDerivedAddressInput{ProgramAddress: program, Seeds: own})
+		indices = append(indices, i)
+		normalized = append(normalized, changed)
+	}
+
+	return run(opts, func(ctx context.Context, yield func()) js.Value {
+		batchOpts := []pda.BatchOption{pda.WithWorkers(1), pda.WithBatchContext(ctx)}
+		if yield != nil {
+			batchOpts = append(batchOpts, pda.WithBatchProgress(paced(yield, nil, 0)))
+		}
+		outputs, errs := pda.FindPDABatch(inputs, batchOpts...)
+		if err := context.Cause(ctx); err != nil {
+			return errorResult(err)
+		}
+		if packed {
+			packResults(opts.output, n, indices, outputs, errs, fail)
+		} else {
+			setResults(results, indices, outputs, errs, normalized, opts)
+		}
+		return results
+	})
+}
+
+// setResults stores the batch outputs (or errors) in results at indices
+func setResults(results js.Value, indices []int, outputs []pda.ProgramDerivedAddressOutput, errs []error, normalized []js.Value, opts callOptions) {
+	for j, i := range indices {
+		if errs[j] != nil {
+			results.SetIndex(i, errorResult(errs[j]))
+			continue
+		}
+		result := jsObject.New()
+		setAddress(result, outputs[j].Address, opts)
+		result.Set("bump", outputs[j].Bump)
+		if !normalized[j].IsUndefined() {
+			result.Set("normalized", normalized[j])
+		}
+		results.SetIndex(i, result)
+	}
+}
+
+// packResults packs the batch outputs into output, one record per
+// request, and reports the errors through fail
+func packResults(output js.Value, n int, indices []int, outputs []pda.ProgramDerivedAddressOutput, errs []error, fail func(int, js.Value)) {
+	buf := make([]byte, n*packedRecordSize)
+	for j, i := range indices {
+		if errs[j] != nil {
+			fail(i, errorResult(errs[j]))
+			continue
+		}
+		record := buf[i*packedRecordSize : (i+1)*packedRecordSize]
+		copy(record, outputs[j].Address[:])
+		record[packedRecordSize-1] = outputs[j].Bump
+	}
+	js.CopyBytesToJS(output, buf)
+}
+
+// createJS derives the address for seeds used as-is, bump included.
+// args: (programId, seedsArray, [options])
+//
+// TypeScript:
+//
+//	create(programId: AddressInput, seeds: SeedInput[], options?: PdaOptions): AddressResult & { normalized?: number[] };
+func createJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return argumentError("args: (programId, seedsArray, [options])")
+	}
+
+	opts, err := parseOptions(args, 2)
+	if err != nil {
+		return inputError(err)
+	}
+
+	program, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+
+	normalized, err := readSeeds(args[1], opts)
+	if err != nil {
+		return inputError(err)
+	}
+
+	if opts.canonicalBump {
+		// The final seed is the bump
+		if len(seeds) == 0 || len(seeds[len(seeds)-1]) != 1 {
+			return argumentError("commitmentToCanonicalBump needs a single-byte bump as the last seed")
+		}
+		if err := pda.CheckCanonicalBump(program, seeds[:len(seeds)-1], seeds[len(seeds)-1][0]); err != nil {
+			return errorResult(err)
+		}
+	}
+
+	addr, err := pda.CreateProgramDerivedAddress(pda.ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds})
+	if err != nil {
+		return errorResult(err)
+	}
+
+	result := jsObject.New()
+	setAddress(result, addr, opts)
+	if !normalized.IsUndefined() {
+		result.Set("normalized", normalized)
+	}
+	return result
+}
+
+// getAllValidBumpsJS returns every bump, 255 down to 0, that yields an
+// off-curve address; the first is the canonical bump.
+// args: (programId, seedsArray, [options])
+//
+// TypeScript:
+//
+//	getAllValidBumps(programId: AddressInput, seeds: SeedInput[], options?: PdaOptions): number[];
+func getAllValidBumpsJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return argumentError("args: (programId, seedsArray, [options])")
+	}
+
+	opts, err := parseOptions(args, 2)
+	if err != nil {
+		return inputError(err)
+	}
+
+	program, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+
+	if _, err := readSeeds(args[1], opts); err != nil {
+		return inputError(err)
+	}
+
+	bumps, err := pda.AllValidBumps(pda.ProgramDerivedAddressInput{ProgramAddress: program, Seeds: seeds})
+	if err != nil {
+		return errorResult(err)
+	}
+
+	result := jsArray.New(len(bumps))
+	for i, bump := range bumps {
+		result.SetIndex(i, bump)
+	}
+	return result
+}
+
+// createAddressWithSeedJS derives the address of an account created with
+// CreateAccountWithSeed, sha256(base || seed || owner).
+// args: (base, seed, owner, [options])
+//
+// TypeScript:
+//
+//	createAddressWithSeed(base: AddressInput, seed: string, owner: AddressInput, options?: PdaOptions): AddressResult;
+func createAddressWithSeedJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 || isBigInt(args[1]) || args[1].Type() != js.TypeString {
+		return argumentError("args: (base, seed, owner, [options])")
+	}
+
+	opts, err := parseOptions(args, 3)
+	if err != nil {
+		return inputError(err)
+	}
+
+	base, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+	owner, err := readAddress(args[2])
+	if err != nil {
+		return inputError(err)
+	}
+
+	addr, err := pda.CreateWithSeed(base, args[1].String(), owner)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	result := jsObject.New()
+	setAddress(result, addr, opts)
+	return result
+}
+
+// isOnCurveJS reports whether a base58 address is a valid ed25519 point
+// (a keypair address rather than a PDA).
+// args: (address)
+//
+// TypeScript:
+//
+//	isOnCurve(address: AddressInput): boolean;
+func isOnCurveJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return argumentError("args: (address)")
+	}
+
+	addr, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+	onCurve, _ := addr.IsOnCurve()
+	return onCurve
+}
+
+// encodeBase58JS encodes bytes of any length as base58.
+// args: (Uint8Array)
+//
+// TypeScript:
+//
+//	encodeBase58(bytes: Uint8Array): string;
+func encodeBase58JS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || !args[0].InstanceOf(jsUint8Array) {
+		return argumentError("args: (Uint8Array)")
+	}
+
+	b := make([]byte, args[0].Length())
+	js.CopyBytesToGo(b, args[0])
+	return base58.Encode(b)
+}
+
+// decodeBase58JS decodes a base58 string of any length to bytes.
+// args: (string)
+//
+// TypeScript:
+//
+//	decodeBase58(text: string): Uint8Array;
+func decodeBase58JS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Type() != js.TypeString {
+		return argumentError("args: (string)")
+	}
+
+	b, err := base58.Decode(args[0].String())
+	if err != nil {
+		return errorResult(pda.ErrInvalidBase58)
+	}
+	out := jsUint8Array.New(len(b))
+	js.CopyBytesToJS(out, b)
+	return out
+}
+
+// pdaVersionJS identifies the build: module version, VCS commit and the
+// marker and limits the bridge derives with.
+// args: ()
+//
+// TypeScript:
+//
+//	type PdaBuild = {
+//	  version: string;
+//	  commit?: string;
+//	  modified?: boolean;
+//	  goVersion: string;
+//	  marker: string;
+//	  maxSeeds: number;
+//	  maxSeedLength: number;
+//	};
+//	pdaVersion(): PdaBuild;
+func pdaVersionJS(this js.Value, args []js.Value) interface{} {
+	b := pda.BuildInfo()
+	result := jsObject.New()
+	result.Set("version", b.Version)
+	if b.Commit != "" {
+		result.Set("commit", b.Commit)
+		result.Set("modified", b.Modified)
+	}
+	result.Set("goVersion", b.GoVersion)
+	result.Set("marker", b.Marker)
+	result.Set("maxSeeds", b.MaxSeeds)
+	result.Set("maxSeedLength", b.MaxSeedLength)
+	return result
+}
+
+// progressOption reads {onProgress, progressIntervalMs} from opts. The
+// callback is invoked synchronously as onProgress(done, rate) while the
+// search runs; the default interval is 250ms. fn is undefined if there is
+// no callback.
+//
+// TypeScript:
+//
+//	type ProgressOptions = { onProgress?: (done: number, rate: number) => void; progressIntervalMs?: number };
+func progressOption(opts js.Value) (fn js.Value, interval time.Duration, err error) {
+	if opts.IsUndefined() || opts.IsNull() {
+		return js.Undefined(), 0, nil
+	}
+	fn = get(opts, "onProgress")
+	if fn.IsUndefined() {
+		return fn, 0, nil
+	}
+	if fn.Type() != js.TypeFunction {
+		return fn, 0, errors.New("onProgress must be a function")
+	}
+
+	interval = 250 * time.Millisecond
+	if ms := get(opts, "progressIntervalMs"); !ms.IsUndefined() {
+		if ms.Type() != js.TypeNumber || !(ms.Float() > 0) || math.IsInf(ms.Float(), 0) {
+			return fn, 0, errors.New("progressIntervalMs must be a positive, finite number")
+		}
+		interval = time.Duration(ms.Float() * float64(time.Millisecond))
+	}
+	return fn, interval, nil
+}
+
+// progressFunc calls the JS function fn with the progress. It runs on a
+// search worker, where an exception thrown by fn cannot be returned, so it
+// cancels the search with the exception as the cause instead.
+func progressFunc(fn js.Value, cancel context.CancelCauseFunc) pda.ProgressFunc {
+	return func(done uint64, rate float64) {
+		defer func() {
+			if r := recover(); r != nil {
+				cancel(fmt.Errorf("onProgress: %v", r))
+			}
+		}()
+		fn.Invoke(float64(done), rate)
+	}
+}
+
+// grindPDAJS searches for a PDA whose address matches {prefix, suffix} by
+// appending an incrementing u64 seed to the fixed seeds. With an
+// abortSignal it returns a Promise.
+// args: (programId, fixedSeeds, {prefix, suffix, onProgress, progressIntervalMs, ...})
+//
+// TypeScript:
+//
+//	type GrindOptions = ProgressOptions & { prefix?: string; suffix?: string };
+//	type GrindResult = AddressResult & { bump: number; seed: Uint8Array };
+//	grind(programId: AddressInput, fixedSeeds: SeedInput[], options: Abortable & GrindOptions): Promise<GrindResult>;
+//	grind(programId: AddressInput, fixedSeeds: SeedInput[], options: PdaOptions & GrindOptions): GrindResult;
+func grindPDAJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 || args[2].IsUndefined() || args[2].IsNull() {
+		return argumentError("args: (programId, fixedSeeds, options)")
+	}
+	opts := args[2]
+	callOpts, err := parseOptions(args, 2)
+	if err != nil {
+		return inputError(err)
+	}
+
+	program, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+
+	if _, err := readSeeds(args[1], callOpts); err != nil {
+		return inputError(err)
+	}
+	// GrindPDA keeps the fixed seeds, so they can't alias the scratch
+	fixed := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		fixed[i] = slices.Clone(seed)
+	}
+
+	var pattern pda.Pattern
+	for _, f := range []struct {
+		name  string
+		field *string
+	}{{"prefix", &pattern.Prefix}, {"suffix", &pattern.Suffix}} {
+		name, field := f.name, f.field
+		v := get(opts, name)
+		if v.IsUndefined() {
+			continue
+		}
+		if isBigInt(v) || v.Type() != js.TypeString {
+			return argumentError(name + " must be a String")
+		}
+		*field = v.String()
+	}
+
+	onProgress, interval, err := progressOption(opts)
+	if err != nil {
+		return argumentError(err.Error())
+	}
+
+	return run(callOpts, func(ctx context.Context, yield func()) js.Value {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		var progress pda.ProgressFunc
+		if !onProgress.IsUndefined() {
+			progress = progressFunc(onProgress, cancel)
+		}
+		var grindOpts []pda.GrindOption
+		if progress, interval := paced(yield, progress, interval); progress != nil {
+			grindOpts = append(grindOpts, pda.WithProgress(progress, interval))
+		}
+
+		res, err := pda.GrindPDA(ctx, program, fixed, pda.VariableSeed{Kind: pda.CounterU64LE}, pattern, 1, grindOpts...)
+		if err != nil {
+			// An abort cancels with context.Canceled; any other cause is
+			// an exception thrown by onProgress
+			if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+				return argumentError(cause.Error())
+			}
+			return errorResult(err)
+		}
+
+		seed := jsUint8Array.New(len(res.Seed))
+		js.CopyBytesToJS(seed, res.Seed)
+
+		result := jsObject.New()
+		setAddress(result, res.Output.Address, callOpts)
+		result.Set("bump", res.Output.Bump)
+		result.Set("seed", seed)
+		return result
+	})
+}
+
+// rangeIndex reads a u64 index given as a Number (a safe integer), BigInt
+// or decimal String
+//
+// TypeScript:
+//
+//	type RangeIndex = number | bigint | string;
+func rangeIndex(val js.Value) (uint64, error) {
+	if !isBigInt(val) && val.Type() == js.TypeNumber {
+		f := val.Float()
+		if f != math.Trunc(f) || f < 0 || f > maxSafeIndex {
+			return 0, fmt.Errorf("index %v is not a non-negative safe integer", f)
+		}
+		return uint64(f), nil
+	}
+	if isBigInt(val) || val.Type() == js.TypeString {
+		n, err := strconv.ParseUint(jsString.Invoke(val).String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("index: %v", err)
+		}
+		return n, nil
+	}
+	return 0, errors.New("index must be a Number, BigInt or String")
+}
+
+// maxSafeIndex is the largest index a Number holds exactly
+const maxSafeIndex = 1<<53 - 1
+
+// indexForm is how a RangeIndex argument was given, and so how indices
+// past maxSafeIndex are returned: as a BigInt or a decimal String
+type indexForm int
+
+const (
+	indexNumber indexForm = iota
+	indexBigInt
+	indexString
+)
+
+// indexFormOf returns the form of the RangeIndex val
+func indexFormOf(val js.Value) indexForm {
+	switch {
+	case isBigInt(val):
+		return indexBigInt
+	case val.Type() == js.TypeString:
+		return indexString
+	}
+	return indexNumber
+}
+
+// indexValue returns index as a Number if it is a safe integer, else in
+// the given form
+func indexValue(index uint64, form indexForm) js.Value {
+	if index <= maxSafeIndex {
+		return js.ValueOf(float64(index))
+	}
+	s := strconv.FormatUint(index, 10)
+	if form == indexBigInt {
+		return jsBigInt.Invoke(s)
+	}
+	return js.ValueOf(s)
+}
+
+// deriveRangeJS derives the PDA for prefixSeeds + u64le(index) for every
+// index in [start, end), returning {index, address, bump} per index.
+// Indices past 2^53 - 1 are returned as BigInts, or as decimal Strings if
+// the range was given as Strings, since a Number would round them. With an
+// abortSignal it returns a Promise.
+// args: (programId, prefixSeeds, start, end, [options])
+//
+// TypeScript:
+//
+//	type RangeResult = AddressResult & { index: RangeIndex; bump: number };
+//	deriveRange(programId: AddressInput, prefixSeeds: SeedInput[], start: RangeIndex, end: RangeIndex, options: Abortable): Promise<(RangeResult | PdaError)[]>;
+//	deriveRange(programId: AddressInput, prefixSeeds: SeedInput[], start: RangeIndex, end: RangeIndex, options?: PdaOptions): (RangeResult | PdaError)[];
+func deriveRangeJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return argumentError("args: (programId, prefixSeeds, start, end, [options])")
+	}
+
+	opts, err := parseOptions(args, 4)
+	if err != nil {
+		return inputError(err)
+	}
+
+	program, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+
+	if _, err := readSeeds(args[1], opts); err != nil {
+		return inputError(err)
+	}
+	// An async range outlives the call, so the seeds can't alias the scratch
+	prefix := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		prefix[i] = slices.Clone(seed)
+	}
+
+	start, err := rangeIndex(args[2])
+	if err != nil {
+		return argumentError("start " + err.Error())
+	}
+	end, err := rangeIndex(args[3])
+	if err != nil {
+		return argumentError("end " + err.Error())
+	}
+	if end < start {
+		return argumentError("end must not be less than start")
+	}
+	// start is a safe integer if it is a Number, so the form of large
+	// indices is taken from end
+	form := indexFormOf(args[2])
+	if form == indexNumber {
+		form = indexFormOf(args[3])
+	}
+
+	return run(opts, func(ctx context.Context, yield func()) js.Value {
+		results := jsArray.New()
+		last := time.Now()
+		for r := range pda.DeriveRange(program, prefix, start, end) {
+			if yield != nil && time.Since(last) >= yieldInterval {
+				yield()
+				last = time.Now()
+			}
+			if err := context.Cause(ctx); err != nil {
+				return errorResult(err)
+			}
+
+			if r.Err != nil {
+				// Only the prefix is rejected outright, with a single
+				// result; a failed index is reported in its place
+				if pda.ErrorCodeOf(r.Err) != pda.CodeNoViableBump {
+					return inputError(r.Err)
+				}
+				e := errorResult(r.Err)
+				e.Set("index", indexValue(r.Index, form))
+				results.Call("push", e)
+				continue
+			}
+
+			result := jsObject.New()
+			result.Set("index", indexValue(r.Index, form))
+			setAddress(result, r.Output.Address, opts)
+			result.Set("bump", r.Output.Bump)
+			results.Call("push", result)
+		}
+		return results
+	})
+}
+
+// SignalReady tells JS the functions are registered: globalThis.__pdaReady
+// becomes a resolved Promise, and where the host has DOM-style events
+// (browsers, workers, Deno) a "pda-ready" Event is dispatched on
+// globalThis. go.run runs main up to its first block before returning, so
+// loaders can await globalThis.__pdaReady right after calling it.
+func SignalReady() {
+	signaled = true
+	global := js.Global()
+	global.Set("__pdaReady", global.Get("Promise").Call("resolve"))
+
+	event := global.Get("Event")
+	if event.Type() == js.TypeFunction && global.Get("dispatchEvent").Type() == js.TypeFunction {
+		global.Call("dispatchEvent", event.New("pda-ready"))
+	}
+}
+
+// signaled records that SignalReady set __pdaReady, for dispose to remove
+var signaled bool
+
+// DefaultNamespace is the global cmd/wasm registers the functions under
+// unless configured otherwise
+const DefaultNamespace = "solanaPda"
+
+// registered holds every js.Func handed to JS, so dispose can release them
+var registered []js.Func
+
+// bridgeFunc is the signature of the functions exposed to JS
+type bridgeFunc func(this js.Value, args []js.Value) interface{}
+
+// methods holds the guarded bridge functions by name, for the worker
+// message handler to dispatch to
+var methods = map[string]bridgeFunc{}
+
+// guard makes fn report a panic as an Error. A panic would take the whole
+// module down with it, so anything the argument checks miss (an exception
+// thrown by a getter, say) fails just this call.
+func guard(fn bridgeFunc) bridgeFunc {
+	return func(this js.Value, args []js.Value) (result interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = argumentError(fmt.Sprint(r))
+			}
+		}()
+		return fn(this, args)
+	}
+}
+
+// namespace is the global the bridge functions are registered under
+var namespace string
+
+// newAPI returns an empty namespace object and the function that adds a
+// bridge function to it
+func newAPI() (api js.Value, set func(name string, fn bridgeFunc)) {
+	api = jsObject.New()
+	wrap := throwingWrapper()
+	return api, func(name string, fn bridgeFunc) {
+		methods[name] = guard(fn)
+		f := js.FuncOf(methods[name])
+		registered = append(registered, f)
+		if wrap.IsNull() {
+			api.Set(name, f)
+		} else {
+			api.Set(name, wrap.Invoke(f))
+		}
+	}
+}
+
+// publish makes api the namespace object globalThis[name]
+func publish(name string, api js.Value) {
+	namespace = name
+	js.Global().Set(name, api)
+}
+
+// Register exposes the bridge functions as methods of globalThis[name],
+// so the module adds a single global. It must be called once; the
+// functions stay registered until Shutdown.
+func Register(name string) {
+	api, set := newAPI()
+	set("find", findJS)
+	set("getProgramDerivedAddresses", getProgramDerivedAddressesJS)
+	set("create", createJS)
+	set("isOnCurve", isOnCurveJS)
+	set("getAllValidBumps", getAllValidBumpsJS)
+	set("createAddressWithSeed", createAddressWithSeedJS)
+	set("grind", grindPDAJS)
+	set("deriveRange", deriveRangeJS)
+	set("encodeBase58", encodeBase58JS)
+	set("decodeBase58", decodeBase58JS)
+	set("pdaVersion", pdaVersionJS)
+	set("stop", stopJS)
+	set("dispose", disposeJS)
+	publish(name, api)
+}
+
+// stopped is closed by Shutdown
+var (
+	stopped  = make(chan struct{})
+	stopOnce sync.Once
+)
+
+// Shutdown tears the bridge down: it unregisters the namespace, releases
+// every callback and cancels the calls still running, whose Promises
+// reject with PDA_ERR_CANCELED; see Wait. Later calls do nothing.
+func Shutdown() {
+	stopOnce.Do(func() {
+		dispose()
+		stopAll(errStopped)
+		close(stopped)
+	})
+}
+
+// stopJS removes the namespace, releases every callback and cancels calls
+// still running (their Promises reject with PDA_ERR_CANCELED), then lets
+// the Go program exit, resolving the promise returned by go.run.
+// args: ()
+//
+// TypeScript:
+//
+//	stop(): void;
+func stopJS(this js.Value, args []js.Value) interface{} {
+	Shutdown()
+	return nil
+}
+
+// disposeJS is the former name of stop, kept for existing callers.
+// args: ()
+//
+// TypeScript:
+//
+//	dispose(): void;
+func disposeJS(this js.Value, args []js.Value) interface{} {
+	return stopJS(this, args)
+}
+
+// dispose removes the namespace and readiness globals and releases every
+// registered callback; calling a stale reference afterwards throws in JS
+// instead of reaching a Go program that has exited
+func dispose() {
+	stopServing()
+	js.Global().Delete(namespace)
+	if signaled {
+		js.Global().Delete("__pdaReady")
+	}
+	for _, f := range registered {
+		f.Release()
+	}
+	registered = nil
+}
+
+// Wait blocks until Shutdown has been called and the calls it canceled
+// have settled. A main that returns afterwards lets the Go program exit
+// and resolves the promise returned by go.run.
+func Wait() {
+	<-stopped
+	inflight.Wait()
+
+	// A yieldToJS can leave a runtime timeout pending for a moment, and
+	// one firing after exit throws in wasm_exec.js; let it fire first
+	time.Sleep(2 * time.Millisecond)
+}
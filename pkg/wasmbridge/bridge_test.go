@@ -0,0 +1,312 @@
+//go:build js && wasm
+
+// The bridge tests run under Node through the Go toolchain's wasm runner:
+//
+//	PATH="$(go env GOROOT)/lib/wasm:$PATH" GOOS=js GOARCH=wasm go test ./pkg/wasmbridge
+package wasmbridge
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"testing"
+	"time"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+func TestMain(m *testing.M) {
+	Register("solanaPdaTest")
+	code := m.Run()
+	Shutdown()
+	os.Exit(code)
+}
+
+var program = pda.ZeroAddress.String()
+
+// uint8Array returns a Uint8Array holding b
+func uint8Array(b []byte) js.Value {
+	arr := jsUint8Array.New(len(b))
+	js.CopyBytesToJS(arr, b)
+	return arr
+}
+
+// goBytes copies the bytes of a Uint8Array
+func goBytes(arr js.Value) []byte {
+	b := make([]byte, arr.Length())
+	js.CopyBytesToGo(b, arr)
+	return b
+}
+
+// call calls fn with args converted by js.ValueOf
+func call(fn bridgeFunc, args ...interface{}) js.Value {
+	vals := make([]js.Value, len(args))
+	for i, arg := range args {
+		vals[i] = js.ValueOf(arg)
+	}
+	return js.ValueOf(fn(js.Undefined(), vals))
+}
+
+// evalJS evaluates a JS function body and returns its result
+func evalJS(body string) js.Value {
+	return js.Global().Get("Function").New(body).Invoke()
+}
+
+// await blocks until the Promise p settles, returning its value and
+// whether it was fulfilled
+func await(t *testing.T, p js.Value) (js.Value, bool) {
+	t.Helper()
+	if !p.InstanceOf(jsPromise) {
+		t.Fatalf("expected a Promise, got %s", jsString.Invoke(p).String())
+	}
+
+	type settled struct {
+		v  js.Value
+		ok bool
+	}
+	ch := make(chan settled, 1)
+	fulfilled := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ch <- settled{args[0], true}
+		return nil
+	})
+	defer fulfilled.Release()
+	rejected := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ch <- settled{args[0], false}
+		return nil
+	})
+	defer rejected.Release()
+	p.Call("then", fulfilled, rejected)
+
+	select {
+	case s := <-ch:
+		return s.v, s.ok
+	case <-time.After(10 * time.Second):
+		t.Fatal("Promise did not settle")
+		return js.Value{}, false
+	}
+}
+
+// checkError fails t unless v is an Error with the given code and, if
+// seedIndex is not negative, that seedIndex
+func checkError(t *testing.T, v js.Value, code pda.ErrorCode, seedIndex int) {
+	t.Helper()
+	if !v.InstanceOf(jsError) {
+		t.Fatalf("expected an Error, got %s", jsString.Invoke(v).String())
+	}
+	if got := v.Get("code").String(); got != string(code) {
+		t.Errorf("code = %s, want %s (%s)", got, code, v.Get("message").String())
+	}
+	if seedIndex < 0 {
+		return
+	}
+	if got := v.Get("seedIndex"); got.Type() != js.TypeNumber || got.Int() != seedIndex {
+		t.Errorf("seedIndex = %s, want %d", jsString.Invoke(got).String(), seedIndex)
+	}
+}
+
+func TestFind_CoercesSeeds(t *testing.T) {
+	view := evalJS(`const b = new Uint8Array([9, 4, 5, 9]); return new DataView(b.buffer, 1, 2);`)
+	got := call(findJS, program, []interface{}{
+		"abc",
+		[]interface{}{1, 2, []interface{}{3}},
+		uint8Array([]byte{4, 5}),
+		view,
+		map[string]interface{}{"type": "u16", "value": "258"},
+	})
+	if got.InstanceOf(jsError) {
+		t.Fatalf("find failed: %s", got.Get("message").String())
+	}
+
+	addr, bump, err := pda.FindPDA(program, [][]byte{[]byte("abc"), {1, 2, 3}, {4, 5}, {4, 5}, {2, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Get("address").String() != addr || got.Get("bump").Int() != int(bump) {
+		t.Errorf("find = %s/%d, want %s/%d", got.Get("address").String(), got.Get("bump").Int(), addr, bump)
+	}
+}
+
+// repeat returns an Array of n copies of seed
+func repeat(seed string, n int) []interface{} {
+	seeds := make([]interface{}, n)
+	for i := range seeds {
+		seeds[i] = seed
+	}
+	return seeds
+}
+
+func TestFind_Errors(t *testing.T) {
+	// base58 has no 0, O, I or l
+	_, badBase58 := pda.NewAddress("0OIl")
+
+	tests := []struct {
+		name      string
+		args      []interface{}
+		code      pda.ErrorCode
+		seedIndex int
+	}{
+		{"missing seeds", []interface{}{program}, pda.CodeInvalidArgument, -1},
+		{"seeds not an Array", []interface{}{program, "abc"}, pda.CodeInvalidArgument, -1},
+		{"options not an object", []interface{}{program, []interface{}{}, 5}, pda.CodeInvalidArgument, -1},
+		{"unknown seed encoding", []interface{}{program, []interface{}{}, map[string]interface{}{"seedEncoding": "rot13"}}, pda.CodeInvalidArgument, -1},
+		{"bad program ID", []interface{}{"0OIl", []interface{}{}}, pda.ErrorCodeOf(badBase58), -1},
+		{"program ID too short", []interface{}{uint8Array(make([]byte, 31)), []interface{}{}}, pda.CodeBadLength, -1},
+		{"byte out of range", []interface{}{program, []interface{}{"a", []interface{}{1, 300}}}, pda.CodeInvalidArgument, 1},
+		{"fractional byte", []interface{}{program, []interface{}{[]interface{}{1.5}}}, pda.CodeInvalidArgument, 0},
+		{"bad hex seed", []interface{}{program, []interface{}{"00", "zz"}, map[string]interface{}{"seedEncoding": "hex"}}, pda.CodeInvalidArgument, 1},
+		{"unknown seed type", []interface{}{program, []interface{}{map[string]interface{}{"type": "u7", "value": 1}}}, pda.CodeInvalidArgument, 0},
+		{"seed too long", []interface{}{program, []interface{}{"a", "b", strings.Repeat("c", pda.MaxSeedLength+1)}}, pda.CodeSeedTooLong, 2},
+		{"too many seeds", []interface{}{program, repeat("x", pda.MaxSeeds)}, pda.CodeMaxSeeds, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, call(findJS, tt.args...), tt.code, tt.seedIndex)
+		})
+	}
+}
+
+func TestGuard_RecoversPanics(t *testing.T) {
+	// A throwing getter panics inside the bridge; guard turns it into an
+	// Error for this call alone
+	seeds := evalJS(`return [{ get type() { throw new Error("boom"); } }];`)
+	got := js.ValueOf(guard(findJS)(js.Undefined(), []js.Value{js.ValueOf(program), seeds}))
+	checkError(t, got, pda.CodeInvalidArgument, -1)
+	if !strings.Contains(got.Get("message").String(), "boom") {
+		t.Errorf("message %q does not mention the exception", got.Get("message").String())
+	}
+}
+
+func TestGetProgramDerivedAddresses_Packed(t *testing.T) {
+	requests := []interface{}{
+		map[string]interface{}{"programId": program, "seeds": []interface{}{"a"}},
+		map[string]interface{}{"programId": program, "seeds": []interface{}{"a", strings.Repeat("b", pda.MaxSeedLength+1)}},
+		"not a request",
+		map[string]interface{}{"programId": program, "seeds": []interface{}{[]interface{}{1, 2}}},
+	}
+	output := uint8Array(bytes.Repeat([]byte{0xff}, len(requests)*packedRecordSize))
+
+	failures := call(getProgramDerivedAddressesJS, requests, map[string]interface{}{"output": output})
+	if !failures.InstanceOf(jsArray) || failures.Length() != 2 {
+		t.Fatalf("expected 2 failures, got %s", jsString.Invoke(failures).String())
+	}
+	checkError(t, failures.Index(0), pda.CodeInvalidArgument, -1)
+	if got := failures.Index(0).Get("index").Int(); got != 2 {
+		t.Errorf("first failure index = %d, want 2", got)
+	}
+	checkError(t, failures.Index(1), pda.CodeSeedTooLong, 1)
+	if got := failures.Index(1).Get("index").Int(); got != 1 {
+		t.Errorf("second failure index = %d, want 1", got)
+	}
+
+	packed := goBytes(output)
+	for i, seeds := range map[int][][]byte{0: {[]byte("a")}, 3: {{1, 2}}} {
+		out, err := pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{Seeds: seeds})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := append(out.Address[:], out.Bump)
+		if got := packed[i*packedRecordSize : (i+1)*packedRecordSize]; !bytes.Equal(got, want) {
+			t.Errorf("record %d = %x, want %x", i, got, want)
+		}
+	}
+	for _, i := range []int{1, 2} {
+		if got := packed[i*packedRecordSize : (i+1)*packedRecordSize]; !bytes.Equal(got, make([]byte, packedRecordSize)) {
+			t.Errorf("failed record %d = %x, want zeros", i, got)
+		}
+	}
+}
+
+func TestGetProgramDerivedAddresses_OutputTooSmall(t *testing.T) {
+	requests := []interface{}{map[string]interface{}{"programId": program, "seeds": []interface{}{}}}
+	got := call(getProgramDerivedAddressesJS, requests, map[string]interface{}{"output": uint8Array(make([]byte, packedRecordSize-1))})
+	checkError(t, got, pda.CodeInvalidArgument, -1)
+}
+
+func TestAbortSignal_AlreadyAborted(t *testing.T) {
+	controller := jsAbortController.New()
+	controller.Call("abort", "too late")
+
+	requests := []interface{}{map[string]interface{}{"programId": program, "seeds": []interface{}{}}}
+	got := call(getProgramDerivedAddressesJS, requests, map[string]interface{}{"abortSignal": controller.Get("signal")})
+	checkError(t, got, pda.CodeCanceled, -1)
+	if !strings.Contains(got.Get("message").String(), "too late") {
+		t.Errorf("message %q does not carry the abort reason", got.Get("message").String())
+	}
+}
+
+func TestAbortSignal_Async(t *testing.T) {
+	controller := jsAbortController.New()
+	requests := []interface{}{map[string]interface{}{"programId": program, "seeds": []interface{}{"a"}}}
+	results, ok := await(t, call(getProgramDerivedAddressesJS, requests, map[string]interface{}{"abortSignal": controller.Get("signal")}))
+	if !ok || results.Length() != 1 || results.Index(0).InstanceOf(jsError) {
+		t.Fatalf("batch with a live signal failed: %s", jsString.Invoke(results).String())
+	}
+}
+
+func TestGrind_Abort(t *testing.T) {
+	controller := jsAbortController.New()
+	// A prefix this long is never found, so only the abort ends the search
+	p := call(grindPDAJS, program, []interface{}{}, map[string]interface{}{
+		"prefix":      "zzzzzzzzzz",
+		"abortSignal": controller.Get("signal"),
+	})
+	time.Sleep(100 * time.Millisecond)
+	controller.Call("abort")
+
+	got, ok := await(t, p)
+	if ok {
+		t.Fatalf("grind succeeded: %s", jsString.Invoke(got).String())
+	}
+	checkError(t, got, pda.CodeCanceled, -1)
+}
+
+func TestDeriveRange_LargeIndices(t *testing.T) {
+	const start = maxSafeIndex - 1 // the ranges cross 2^53
+	typeOf := js.Global().Get("Function").New("v", "return typeof v")
+	tests := []struct {
+		name       string
+		start, end interface{}
+		want       []string // typeof each index
+	}{
+		{"Numbers", float64(start - 2), float64(start), []string{"number", "number"}},
+		{"BigInt", js.Global().Get("BigInt").Invoke(start), js.Global().Get("BigInt").Invoke("9007199254740994"), []string{"number", "number", "bigint", "bigint"}},
+		{"String", "9007199254740990", "9007199254740993", []string{"number", "number", "string"}},
+		{"Number start, BigInt end", float64(start), js.Global().Get("BigInt").Invoke("9007199254740993"), []string{"number", "number", "bigint"}},
+		{"Number start, String end", float64(maxSafeIndex), "9007199254740994", []string{"number", "string", "string"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := call(deriveRangeJS, program, []interface{}{"r"}, tt.start, tt.end)
+			if !results.InstanceOf(jsArray) || results.Length() != len(tt.want) {
+				t.Fatalf("deriveRange = %s, want %d results", jsString.Invoke(results).String(), len(tt.want))
+			}
+			first, err := rangeIndex(js.ValueOf(tt.start))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, want := range tt.want {
+				index := results.Index(i).Get("index")
+				if got := typeOf.Invoke(index).String(); got != want {
+					t.Errorf("index %d is a %s, want a %s", i, got, want)
+				}
+				if got := jsString.Invoke(index).String(); got != strconv.FormatUint(first+uint64(i), 10) {
+					t.Errorf("index %d = %s, want %d", i, got, first+uint64(i))
+				}
+			}
+		})
+	}
+}
+
+func TestGrind_InvalidPattern(t *testing.T) {
+	for _, opts := range []map[string]interface{}{
+		{"prefix": "0"},
+		{"suffix": "Il"},
+		{"prefix": strings.Repeat("z", 45)},
+	} {
+		checkError(t, call(grindPDAJS, program, []interface{}{}, opts), pda.CodeInvalidArgument, -1)
+	}
+}
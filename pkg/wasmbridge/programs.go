@@ -0,0 +1,186 @@
+//go:build js && wasm
+
+package wasmbridge
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"raccoon-wasm/pkg/pda"
+	"raccoon-wasm/pkg/pda/programs"
+)
+
+// DefaultProgramsNamespace is the global cmd/wasmprograms registers the
+// protocol helpers under unless configured otherwise
+const DefaultProgramsNamespace = "solanaPdaPrograms"
+
+// RegisterPrograms exposes the protocol helpers of package programs as
+// methods of globalThis[name]. It takes the place of Register in the
+// secondary module built by cmd/wasmprograms; Register refers to none of
+// the helpers, so the core module doesn't link them.
+func RegisterPrograms(name string) {
+	api, set := newAPI()
+	set("getAssociatedTokenAddress", getAssociatedTokenAddressJS)
+	set("getAssociatedTokenAddress2022", getAssociatedTokenAddress2022JS)
+	set("findMetadataPda", findMetadataPDAJS)
+	set("findMasterEditionPda", findMasterEditionPDAJS)
+	set("findEditionMarkerPda", findEditionMarkerPDAJS)
+	set("findCandyMachineAuthorityPda", findCandyMachineAuthorityPDAJS)
+	set("findCandyGuardPda", findCandyGuardPDAJS)
+	set("pdaVersion", pdaVersionJS)
+	set("stop", stopJS)
+	publish(name, api)
+}
+
+// getAssociatedTokenAddressJS derives the associated token account of
+// owner for mint. The mint is taken to belong to the classic Token program
+// unless options.tokenProgram names another, such as Token-2022.
+// args: (owner, mint, [options])
+//
+// TypeScript:
+//
+//	type AtaOptions = PdaOptions & { tokenProgram?: AddressInput };
+//	getAssociatedTokenAddress(owner: AddressInput, mint: AddressInput, options?: AtaOptions): PdaResult;
+func getAssociatedTokenAddressJS(this js.Value, args []js.Value) interface{} {
+	tokenProgram := programs.TokenProgramID
+	if len(args) > 2 && !isBigInt(args[2]) && args[2].Type() == js.TypeObject {
+		if v := get(args[2], "tokenProgram"); !v.IsUndefined() {
+			var err error
+			if tokenProgram, err = readAddress(v); err != nil {
+				return inputError(fmt.Errorf("tokenProgram: %w", err))
+			}
+		}
+	}
+	return associatedTokenAddress(args, tokenProgram)
+}
+
+// getAssociatedTokenAddress2022JS derives the associated token account of
+// owner for a mint of the Token-2022 program.
+// args: (owner, mint, [options])
+//
+// TypeScript:
+//
+//	getAssociatedTokenAddress2022(owner: AddressInput, mint: AddressInput, options?: PdaOptions): PdaResult;
+func getAssociatedTokenAddress2022JS(this js.Value, args []js.Value) interface{} {
+	return associatedTokenAddress(args, programs.Token2022ProgramID)
+}
+
+func associatedTokenAddress(args []js.Value, tokenProgram pda.Address) interface{} {
+	if len(args) < 2 {
+		return argumentError("args: (owner, mint, [options])")
+	}
+
+	opts, err := parseOptions(args, 2)
+	if err != nil {
+		return inputError(err)
+	}
+
+	owner, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+	mint, err := readAddress(args[1])
+	if err != nil {
+		return inputError(err)
+	}
+
+	out, err := programs.GetAssociatedTokenAddressWithProgram(owner, mint, tokenProgram)
+	if err != nil {
+		return errorResult(err)
+	}
+	return programResult(out, opts)
+}
+
+// findMetadataPDAJS derives the Metaplex metadata account of a mint.
+// args: (mint, [options])
+//
+// TypeScript:
+//
+//	findMetadataPda(mint: AddressInput, options?: PdaOptions): PdaResult;
+func findMetadataPDAJS(this js.Value, args []js.Value) interface{} {
+	return addressPDA(args, "mint", programs.FindMetadataPDA)
+}
+
+// findMasterEditionPDAJS derives the Metaplex master edition account of a
+// mint.
+// args: (mint, [options])
+//
+// TypeScript:
+//
+//	findMasterEditionPda(mint: AddressInput, options?: PdaOptions): PdaResult;
+func findMasterEditionPDAJS(this js.Value, args []js.Value) interface{} {
+	return addressPDA(args, "mint", programs.FindMasterEditionPDA)
+}
+
+// findEditionMarkerPDAJS derives the Metaplex edition marker account that
+// records whether an edition of a master edition mint was printed.
+// args: (mint, edition, [options])
+//
+// TypeScript:
+//
+//	findEditionMarkerPda(mint: AddressInput, edition: RangeIndex, options?: PdaOptions): PdaResult;
+func findEditionMarkerPDAJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return argumentError("args: (mint, edition, [options])")
+	}
+	edition, err := rangeIndex(args[1])
+	if err != nil {
+		return argumentError("edition " + err.Error())
+	}
+	return addressPDA(append([]js.Value{args[0]}, args[2:]...), "mint", func(mint pda.Address) (pda.ProgramDerivedAddressOutput, error) {
+		return programs.FindEditionMarkerPDA(mint, edition)
+	})
+}
+
+// findCandyMachineAuthorityPDAJS derives the authority PDA of a Candy
+// Machine v3, which mint instructions take as an account.
+// args: (candyMachine, [options])
+//
+// TypeScript:
+//
+//	findCandyMachineAuthorityPda(candyMachine: AddressInput, options?: PdaOptions): PdaResult;
+func findCandyMachineAuthorityPDAJS(this js.Value, args []js.Value) interface{} {
+	return addressPDA(args, "candyMachine", programs.FindCandyMachineAuthorityPDA)
+}
+
+// findCandyGuardPDAJS derives the candy guard account created from base.
+// args: (base, [options])
+//
+// TypeScript:
+//
+//	findCandyGuardPda(base: AddressInput, options?: PdaOptions): PdaResult;
+func findCandyGuardPDAJS(this js.Value, args []js.Value) interface{} {
+	return addressPDA(args, "base", programs.FindCandyGuardPDA)
+}
+
+// addressPDA reads (<param>, [options]) and returns the PDA derive finds
+// for the address
+func addressPDA(args []js.Value, param string, derive func(pda.Address) (pda.ProgramDerivedAddressOutput, error)) interface{} {
+	if len(args) < 1 {
+		return argumentError("args: (" + param + ", [options])")
+	}
+
+	opts, err := parseOptions(args, 1)
+	if err != nil {
+		return inputError(err)
+	}
+
+	addr, err := readAddress(args[0])
+	if err != nil {
+		return inputError(err)
+	}
+
+	out, err := derive(addr)
+	if err != nil {
+		return errorResult(err)
+	}
+	return programResult(out, opts)
+}
+
+// programResult converts a helper's PDA to {address, bump}
+func programResult(out pda.ProgramDerivedAddressOutput, opts callOptions) js.Value {
+	result := jsObject.New()
+	setAddress(result, out.Address, opts)
+	result.Set("bump", out.Bump)
+	return result
+}
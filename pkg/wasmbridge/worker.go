@@ -0,0 +1,332 @@
+//go:build js && wasm
+
+package wasmbridge
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"syscall/js"
+)
+
+// Message mode. Where direct calls are not possible, the functions are
+// driven by postMessage: pda-worker.js starts the module in a Web Worker
+// and pda-frame.html in a (possibly sandboxed) iframe, with go.env.PDA_MODE
+// set, and cmd/wasm then calls ServeMessages to serve calls posted to them:
+//
+//	→ {id, method, params}      call solanaPda[method](...params)
+//	→ {id, cancel: true}        abort call id if it is still running
+//	← {ready: true}             once the functions are registered
+//	← {id, result}
+//	← {id, error: {message, code, seedIndex, index}}
+//	← {id, progress: {done, rate}}  grind with {onProgress: true}
+//
+// args is accepted in place of params. Replies go to whoever posted the
+// request: the worker's owner, or the window that messaged the iframe. A
+// frame only serves the origins it is given, since any page can embed it
+// and the protocol includes stop.
+// getProgramDerivedAddresses, deriveRange and grind run asynchronously, so
+// messages (cancels included) keep being handled while they run.
+// Uint8Arrays in results, such as seed and addressBytes, are transferred
+// rather than copied, and Errors in batch results become {error: {...}}.
+
+var jsAbortController = js.Global().Get("AbortController")
+
+// cancellable maps the methods that run asynchronously given an
+// abortSignal to the index of their options argument
+var cancellable = map[string]int{
+	"getProgramDerivedAddresses": 1,
+	"deriveRange":                4,
+	"grind":                      2,
+}
+
+// running holds the AbortController of each cancellable call in flight,
+// by peer.key
+var running = map[string]js.Value{}
+
+// peer is the sender of a request, and so the receiver of its replies
+type peer struct {
+	// source is the window that posted the request, or undefined for the
+	// owner of a worker
+	source js.Value
+	origin string
+}
+
+// key identifies call id of the peer, since windows choose ids
+// independently
+func (p peer) key(id js.Value) string {
+	return p.origin + " " + jsString.Invoke(id).String()
+}
+
+// post sends msg to the peer, transferring the buffers listed in transfer
+// (if defined)
+func (p peer) post(msg, transfer js.Value) {
+	if p.source.IsUndefined() {
+		post(msg, transfer)
+		return
+	}
+	if transfer.IsUndefined() {
+		p.source.Call("postMessage", msg, targetOrigin(p.origin))
+		return
+	}
+	p.source.Call("postMessage", msg, targetOrigin(p.origin), transfer)
+}
+
+// targetOrigin returns the postMessage targetOrigin for a window of the
+// given origin. An opaque origin ("null", e.g. of a sandboxed frame) can
+// only be targeted with "*"; the message still goes to that one window.
+func targetOrigin(origin string) string {
+	if origin == "" || origin == "null" {
+		return "*"
+	}
+	return origin
+}
+
+// onMessage is the worker's message listener, removed by dispose
+var onMessage js.Func
+
+// errNoMessaging is returned by ServeMessages where the global scope cannot
+// exchange messages, as on Node's main thread and in worker_threads
+var errNoMessaging = errors.New("message mode needs a global postMessage and addEventListener (a window, or a Web, Deno or Bun worker)")
+
+// errNoOrigins is returned by ServeMessages in a window given no origins
+var errNoOrigins = errors.New("message mode in a window needs the origins allowed to call it")
+
+// ServeMessages starts answering the message protocol. Call it after
+// Register, from a module running in a worker or an iframe. Messages from
+// other windows are only served if origins lists their origin, and in a
+// window (an iframe) origins must not be empty; a worker's owner is
+// always served.
+//
+// TypeScript:
+//
+//	type PdaWorkerRequest =
+//	  | { id: unknown; method: keyof SolanaPda; params?: unknown[]; args?: unknown[] }
+//	  | { id: unknown; cancel: true };
+//	type PdaWorkerReply =
+//	  | { ready: true }
+//	  | { id: unknown; result: unknown }
+//	  | { id: unknown; error: { message: string; code: PdaErrorCode; seedIndex?: number; index?: RangeIndex } }
+//	  | { id: unknown; progress: { done: number; rate: number } };
+func ServeMessages(origins ...string) error {
+	global := js.Global()
+	if global.Get("postMessage").Type() != js.TypeFunction || global.Get("addEventListener").Type() != js.TypeFunction {
+		return errNoMessaging
+	}
+	// Workers have no parent; windows, framed or not, have one
+	parent := global.Get("parent")
+	inWindow := parent.Type() == js.TypeObject
+	if inWindow && len(origins) == 0 {
+		return errNoOrigins
+	}
+
+	onMessage = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		from := peer{source: js.Undefined()}
+		// A worker's messages have no source; a window's carry it and
+		// their origin
+		if source := event.Get("source"); source.Type() == js.TypeObject {
+			from.source = source
+			if origin := event.Get("origin"); origin.Type() == js.TypeString {
+				from.origin = origin.String()
+			}
+			if !slices.Contains(origins, from.origin) {
+				return nil
+			}
+		}
+		handleMessage(event.Get("data"), from)
+		return nil
+	})
+	registered = append(registered, onMessage)
+	global.Call("addEventListener", "message", onMessage)
+
+	ready := jsObject.New()
+	ready.Set("ready", true)
+	// In an iframe the embedding window is told; elsewhere the worker's
+	// owner
+	if inWindow && !parent.Equal(global) {
+		for _, origin := range origins {
+			parent.Call("postMessage", ready, targetOrigin(origin))
+		}
+		return nil
+	}
+	post(ready, js.Undefined())
+	return nil
+}
+
+// stopServing removes the message listener, if ServeMessages added one
+func stopServing() {
+	if !onMessage.IsUndefined() {
+		js.Global().Call("removeEventListener", "message", onMessage)
+		onMessage = js.Func{}
+	}
+}
+
+// handleMessage runs one request and posts its reply
+func handleMessage(msg js.Value, from peer) {
+	if isBigInt(msg) || msg.Type() != js.TypeObject {
+		return
+	}
+	id := get(msg, "id")
+	key := from.key(id)
+	defer func() {
+		if r := recover(); r != nil {
+			reply(from, id, argumentError(fmt.Sprint(r)))
+		}
+	}()
+
+	if get(msg, "cancel").Truthy() {
+		if controller, ok := running[key]; ok {
+			controller.Call("abort")
+		}
+		return
+	}
+
+	name := get(msg, "method")
+	fn, ok := methods[jsString.Invoke(name).String()]
+	if isBigInt(name) || name.Type() != js.TypeString || !ok {
+		reply(from, id, argumentError(fmt.Sprintf("unknown method %s", jsString.Invoke(name).String())))
+		return
+	}
+
+	list := get(msg, "params")
+	if list.IsUndefined() {
+		list = get(msg, "args")
+	}
+	var args []js.Value
+	if list.InstanceOf(jsArray) {
+		for i, n := 0, list.Length(); i < n; i++ {
+			args = append(args, list.Index(i))
+		}
+	}
+
+	// Functions can't be posted, so the bridge supplies the abortSignal
+	// and, for onProgress: true, a callback that posts progress messages
+	var progress js.Func
+	if i, ok := cancellable[name.String()]; ok {
+		for len(args) <= i {
+			args = append(args, js.Undefined())
+		}
+		if args[i].IsUndefined() || args[i].IsNull() {
+			args[i] = jsObject.New()
+		}
+		if opts := args[i]; !isBigInt(opts) && opts.Type() == js.TypeObject {
+			controller := jsAbortController.New()
+			opts.Set("abortSignal", controller.Get("signal"))
+			running[key] = controller
+
+			if get(opts, "onProgress").Equal(js.ValueOf(true)) {
+				progress = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+					p := jsObject.New()
+					p.Set("done", args[0])
+					p.Set("rate", args[1])
+					m := jsObject.New()
+					m.Set("id", id)
+					m.Set("progress", p)
+					from.post(m, js.Undefined())
+					return nil
+				})
+				opts.Set("onProgress", progress)
+			}
+		}
+	}
+
+	done := func(result js.Value) {
+		delete(running, key)
+		if !progress.IsUndefined() {
+			progress.Release()
+		}
+		reply(from, id, result)
+	}
+
+	result := js.ValueOf(fn(js.Undefined(), args))
+	if !result.InstanceOf(jsPromise) {
+		done(result)
+		return
+	}
+
+	var fulfilled, rejected js.Func
+	settle := func(v js.Value) {
+		fulfilled.Release()
+		rejected.Release()
+		done(v)
+	}
+	fulfilled = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		settle(args[0])
+		return nil
+	})
+	rejected = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		settle(args[0])
+		return nil
+	})
+	result.Call("then", fulfilled, rejected)
+}
+
+// reply posts the result of call id, or its error, to the peer
+func reply(to peer, id, result js.Value) {
+	msg := jsObject.New()
+	msg.Set("id", id)
+	if result.InstanceOf(jsError) {
+		msg.Set("error", plainError(result))
+		to.post(msg, js.Undefined())
+		return
+	}
+
+	transfer := jsArray.New()
+	if result.InstanceOf(jsArray) {
+		for i, n := 0, result.Length(); i < n; i++ {
+			item := result.Index(i)
+			if item.InstanceOf(jsError) {
+				wrapped := jsObject.New()
+				wrapped.Set("error", plainError(item))
+				result.SetIndex(i, wrapped)
+				continue
+			}
+			collectBuffers(item, transfer)
+		}
+	} else {
+		collectBuffers(result, transfer)
+	}
+	msg.Set("result", result)
+	to.post(msg, transfer)
+}
+
+// plainError copies the fields of a bridge Error into a plain object,
+// since structured cloning drops an Error's own properties such as code
+func plainError(e js.Value) js.Value {
+	p := jsObject.New()
+	for _, name := range []string{"message", "code", "seedIndex", "index"} {
+		if v := e.Get(name); !v.IsUndefined() {
+			p.Set(name, v)
+		}
+	}
+	return p
+}
+
+// collectBuffers adds the buffer of v, if it is a Uint8Array, or of each
+// Uint8Array property of v to transfer
+func collectBuffers(v js.Value, transfer js.Value) {
+	if v.Type() != js.TypeObject {
+		return
+	}
+	if v.InstanceOf(jsUint8Array) {
+		transfer.Call("push", v.Get("buffer"))
+		return
+	}
+	keys := jsObject.Call("keys", v)
+	for i, n := 0, keys.Length(); i < n; i++ {
+		if prop := v.Get(keys.Index(i).String()); prop.InstanceOf(jsUint8Array) {
+			transfer.Call("push", prop.Get("buffer"))
+		}
+	}
+}
+
+// post sends msg through the global postMessage, to the worker's owner,
+// transferring the buffers listed in transfer (if defined)
+func post(msg, transfer js.Value) {
+	if transfer.IsUndefined() {
+		js.Global().Call("postMessage", msg)
+		return
+	}
+	js.Global().Call("postMessage", msg, transfer)
+}
@@ -0,0 +1,235 @@
+//go:build js && wasm
+
+package wasmbridge
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+
+	"raccoon-wasm/pkg/pda"
+)
+
+// stubMessaging gives the global scope the postMessage and
+// addEventListener of a worker, recording what is posted, and returns the
+// harness: posted holds {msg, rest} per message, listener the message
+// listener, and window(origin) makes a window that records the same way.
+// parent makes the scope a window framed by parent.
+func stubMessaging(t *testing.T, parent bool) js.Value {
+	t.Helper()
+	h := evalJS(`
+		const record = (target) => {
+			target.posted = [];
+			target.postMessage = (msg, ...rest) => { target.posted.push({ msg, rest }); };
+			return target;
+		};
+		const h = record({ listener: null });
+		globalThis.postMessage = h.postMessage;
+		globalThis.addEventListener = (type, fn) => { if (type === "message") h.listener = fn; };
+		globalThis.removeEventListener = (type, fn) => { if (h.listener === fn) h.listener = null; };
+		h.window = (origin) => record({ origin });
+		return h;`)
+	if parent {
+		js.Global().Set("parent", h.Call("window", ""))
+	}
+	t.Cleanup(func() {
+		stopServing()
+		for _, name := range []string{"postMessage", "addEventListener", "removeEventListener", "parent"} {
+			js.Global().Delete(name)
+		}
+	})
+	return h
+}
+
+// stringify returns v as JSON, for failure messages
+func stringify(v js.Value) string {
+	return js.Global().Get("JSON").Call("stringify", v).String()
+}
+
+// dispatch delivers a message event to the listener ServeMessages added;
+// source is undefined for a message from the worker's owner
+func dispatch(t *testing.T, h js.Value, data map[string]interface{}, source js.Value) {
+	t.Helper()
+	listener := h.Get("listener")
+	if listener.Type() != js.TypeFunction {
+		t.Fatal("no message listener")
+	}
+	event := jsObject.New()
+	event.Set("data", js.ValueOf(data))
+	if !source.IsUndefined() {
+		event.Set("source", source)
+		event.Set("origin", source.Get("origin"))
+	}
+	listener.Invoke(event)
+}
+
+// replyTo waits for the reply to call id among the messages target
+// recorded, returning it and the arguments posted after it
+func replyTo(t *testing.T, target js.Value, id int) (msg, rest js.Value) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		posted := target.Get("posted")
+		for i := 0; i < posted.Length(); i++ {
+			p := posted.Index(i)
+			if m := p.Get("msg"); m.Get("id").Equal(js.ValueOf(id)) && m.Get("progress").IsUndefined() {
+				return m, p.Get("rest")
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no reply to call %d", id)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestServeMessages_NeedsMessaging(t *testing.T) {
+	if err := ServeMessages(); err != errNoMessaging {
+		t.Errorf("ServeMessages() without postMessage = %v, want %v", err, errNoMessaging)
+	}
+}
+
+func TestServeMessages_Worker(t *testing.T) {
+	h := stubMessaging(t, false)
+	if err := ServeMessages(); err != nil {
+		t.Fatal(err)
+	}
+	if posted := h.Get("posted"); posted.Length() != 1 || !posted.Index(0).Get("msg").Get("ready").Truthy() {
+		t.Fatalf("expected {ready: true}, got %s", stringify(posted))
+	}
+
+	t.Run("result", func(t *testing.T) {
+		dispatch(t, h, map[string]interface{}{
+			"id":     1,
+			"method": "find",
+			"params": []interface{}{program, []interface{}{"a"}, map[string]interface{}{"includeBytes": true}},
+		}, js.Undefined())
+		msg, rest := replyTo(t, h, 1)
+
+		out, err := pda.GetProgramDerivedAddress(pda.ProgramDerivedAddressInput{Seeds: [][]byte{[]byte("a")}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := msg.Get("result")
+		if result.Get("address").String() != out.Address.String() || result.Get("bump").Int() != int(out.Bump) {
+			t.Errorf("result = %s, want %s/%d", stringify(result), out.Address, out.Bump)
+		}
+		// The addressBytes buffer is transferred, not copied
+		transfer := rest.Index(0)
+		if transfer.Length() != 1 || !transfer.Index(0).Equal(result.Get("addressBytes").Get("buffer")) {
+			t.Errorf("transfer list does not hold the addressBytes buffer")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		dispatch(t, h, map[string]interface{}{"id": 2, "method": "nope"}, js.Undefined())
+		msg, _ := replyTo(t, h, 2)
+		if got := msg.Get("error").Get("code").String(); got != string(pda.CodeInvalidArgument) {
+			t.Errorf("unknown method code = %s, want %s", got, pda.CodeInvalidArgument)
+		}
+
+		dispatch(t, h, map[string]interface{}{
+			"id":     3,
+			"method": "find",
+			"params": []interface{}{program, []interface{}{"a", []interface{}{256}}},
+		}, js.Undefined())
+		msg, _ = replyTo(t, h, 3)
+		e := msg.Get("error")
+		if e.Get("code").String() != string(pda.CodeInvalidArgument) || e.Get("seedIndex").Int() != 1 {
+			t.Errorf("error = %s, want %s at seed 1", stringify(e), pda.CodeInvalidArgument)
+		}
+	})
+
+	t.Run("batch errors", func(t *testing.T) {
+		dispatch(t, h, map[string]interface{}{
+			"id":     4,
+			"method": "getProgramDerivedAddresses",
+			"params": []interface{}{[]interface{}{
+				map[string]interface{}{"programId": program, "seeds": []interface{}{}},
+				map[string]interface{}{"programId": "0OIl", "seeds": []interface{}{}},
+			}},
+		}, js.Undefined())
+		msg, _ := replyTo(t, h, 4)
+		results := msg.Get("result")
+		if results.Index(0).Get("address").IsUndefined() {
+			t.Errorf("first result has no address")
+		}
+		// Errors become plain objects, since cloning drops their code
+		if e := results.Index(1).Get("error"); e.IsUndefined() || e.InstanceOf(jsError) || e.Get("code").IsUndefined() {
+			t.Errorf("second result = %s, want {error: {...}}", stringify(results.Index(1)))
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		dispatch(t, h, map[string]interface{}{
+			"id":     5,
+			"method": "grind",
+			"params": []interface{}{program, []interface{}{}, map[string]interface{}{"prefix": "zzzzzzzzzz", "onProgress": true, "progressIntervalMs": 10}},
+		}, js.Undefined())
+		key := peer{source: js.Undefined()}.key(js.ValueOf(5))
+		if _, ok := running[key]; !ok {
+			t.Fatal("grind is not tracked as running")
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		dispatch(t, h, map[string]interface{}{"id": 5, "cancel": true}, js.Undefined())
+		msg, _ := replyTo(t, h, 5)
+		if got := msg.Get("error").Get("code").String(); got != string(pda.CodeCanceled) {
+			t.Errorf("canceled grind code = %s, want %s", got, pda.CodeCanceled)
+		}
+		if len(running) != 0 {
+			t.Errorf("running still holds %d calls", len(running))
+		}
+
+		// Progress was posted while the grind ran
+		progressed := false
+		posted := h.Get("posted")
+		for i := 0; i < posted.Length(); i++ {
+			if m := posted.Index(i).Get("msg"); m.Get("id").Equal(js.ValueOf(5)) && !m.Get("progress").IsUndefined() {
+				progressed = true
+			}
+		}
+		if !progressed {
+			t.Error("no progress messages were posted")
+		}
+	})
+}
+
+func TestServeMessages_WindowOrigins(t *testing.T) {
+	h := stubMessaging(t, true)
+	if err := ServeMessages(); err != errNoOrigins {
+		t.Fatalf("ServeMessages() in a window = %v, want %v", err, errNoOrigins)
+	}
+
+	const allowed = "https://app.example"
+	if err := ServeMessages(allowed); err != nil {
+		t.Fatal(err)
+	}
+	// The embedding window is told, at the allowed origin only
+	ready := js.Global().Get("parent").Get("posted")
+	if ready.Length() != 1 || ready.Index(0).Get("rest").Index(0).String() != allowed {
+		t.Fatalf("ready = %s, want one message to %s", stringify(ready), allowed)
+	}
+
+	find := map[string]interface{}{"id": 1, "method": "find", "params": []interface{}{program, []interface{}{}}}
+
+	other := h.Call("window", "https://evil.example")
+	dispatch(t, h, find, other)
+	time.Sleep(20 * time.Millisecond)
+	if n := other.Get("posted").Length(); n != 0 {
+		t.Errorf("a window outside the allowlist got %d replies", n)
+	}
+
+	app := h.Call("window", allowed)
+	dispatch(t, h, find, app)
+	msg, rest := replyTo(t, app, 1)
+	if msg.Get("result").Get("address").IsUndefined() {
+		t.Errorf("allowed window got %s", stringify(msg))
+	}
+	if got := rest.Index(0).String(); got != allowed {
+		t.Errorf("reply targetOrigin = %q, want %q", got, allowed)
+	}
+	if n := h.Get("posted").Length(); n != 0 {
+		t.Errorf("%d replies went to the global postMessage instead of the source window", n)
+	}
+}